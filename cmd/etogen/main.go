@@ -0,0 +1,295 @@
+// Command etogen สร้าง decorator ของ interface ที่ใส่ span + duration histogram ให้ทุก method
+// ผ่าน eto.StartCall (span "<Interface>.<Method>", record error, method_duration_ms)
+//
+// ใช้กับ go:generate ในไฟล์ที่ประกาศ interface:
+//
+//	//go:generate go run github.com/Maximumsoft-Co-LTD/otelgo/cmd/etogen -type UserRepository
+//
+// จะได้ไฟล์ userrepository_eto.go ที่มี
+//
+//	type UserRepositoryWithEto struct{ ... }
+//	func NewUserRepositoryWithEto(next UserRepository) *UserRepositoryWithEto
+//
+// method ที่รับ context.Context เป็น parameter แรกจะส่ง ctx ที่มี span ต่อให้ next
+// ส่วน error ดูจาก result ตัวสุดท้ายที่เป็นชนิด error
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const etoImport = "github.com/Maximumsoft-Co-LTD/otelgo/eto"
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("etogen: ")
+
+	typeName := flag.String("type", "", "interface name to decorate (required)")
+	source := flag.String("source", os.Getenv("GOFILE"), "file that declares the interface (default $GOFILE)")
+	output := flag.String("output", "", "output file (default <type>_eto.go next to source)")
+	component := flag.String("component", "", "span / metric component name (default type name)")
+	flag.Parse()
+
+	if *typeName == "" || *source == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = filepath.Join(filepath.Dir(*source), strings.ToLower(*typeName)+"_eto.go")
+	}
+	if *component == "" {
+		*component = *typeName
+	}
+
+	src, err := generate(*source, *typeName, *component)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generator struct {
+	fset    *token.FileSet
+	imports map[string]string // ชื่อที่ใช้ในไฟล์ → import path
+	used    map[string]struct{}
+	buf     bytes.Buffer
+}
+
+func generate(source, typeName, component string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	iface, err := findInterface(file, typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{fset: fset, imports: fileImports(file), used: map[string]struct{}{}}
+	var body bytes.Buffer
+	for _, m := range iface.Methods.List {
+		ft, ok := m.Type.(*ast.FuncType)
+		if !ok {
+			return nil, fmt.Errorf("%s: embedded interfaces are not supported (%s)", typeName, g.expr(m.Type))
+		}
+		for _, name := range m.Names {
+			g.method(&body, typeName, component, name.Name, ft)
+		}
+	}
+
+	wrapper := typeName + "WithEto"
+	fmt.Fprintf(&g.buf, "// Code generated by etogen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&g.buf, "package %s\n\n", file.Name.Name)
+	g.writeImports()
+	fmt.Fprintf(&g.buf, "// %s ห่อ %s ด้วย span + method_duration_ms ต่อ method\n", wrapper, typeName)
+	fmt.Fprintf(&g.buf, "type %s struct {\n\tnext %s\n}\n\n", wrapper, typeName)
+	fmt.Fprintf(&g.buf, "var _ %s = (*%s)(nil)\n\n", typeName, wrapper)
+	fmt.Fprintf(&g.buf, "// New%s ห่อ next (component = %q)\n", wrapper, component)
+	fmt.Fprintf(&g.buf, "func New%s(next %s) *%s {\n\treturn &%s{next: next}\n}\n", wrapper, typeName, wrapper, wrapper)
+	g.buf.Write(body.Bytes())
+
+	out, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, g.buf.Bytes())
+	}
+	return out, nil
+}
+
+func findInterface(file *ast.File, name string) (*ast.InterfaceType, error) {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != name {
+				continue
+			}
+			if ts.TypeParams != nil {
+				return nil, fmt.Errorf("%s: generic interfaces are not supported", name)
+			}
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok {
+				return nil, fmt.Errorf("%s is not an interface", name)
+			}
+			return iface, nil
+		}
+	}
+	return nil, errors.New(name + ": interface not found")
+}
+
+func fileImports(file *ast.File) map[string]string {
+	out := map[string]string{}
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		out[name] = path
+	}
+	return out
+}
+
+// expr พิมพ์ type expression ตามที่เขียนในไฟล์ต้นทาง และจำ package ที่ถูกอ้างถึงไว้ใส่ import
+func (g *generator) expr(e ast.Expr) string {
+	ast.Inspect(e, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				g.used[id.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+	var b bytes.Buffer
+	_ = printer.Fprint(&b, g.fset, e)
+	return b.String()
+}
+
+func (g *generator) writeImports() {
+	paths := map[string]string{"context": "", "eto": etoImport}
+	for name := range g.used {
+		if path, ok := g.imports[name]; ok {
+			paths[name] = path
+		}
+	}
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// stdlib ก่อน แล้วเว้นบรรทัดค่อย module อื่น (แบบ goimports)
+	var std, other []string
+	for _, name := range names {
+		path := firstNonEmpty(paths[name], name)
+		line := fmt.Sprintf("\t%q\n", path)
+		if path[strings.LastIndex(path, "/")+1:] != name {
+			line = fmt.Sprintf("\t%s %q\n", name, path)
+		}
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			other = append(other, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+
+	g.buf.WriteString("import (\n")
+	g.buf.WriteString(strings.Join(std, ""))
+	if len(std) > 0 && len(other) > 0 {
+		g.buf.WriteString("\n")
+	}
+	g.buf.WriteString(strings.Join(other, ""))
+	g.buf.WriteString(")\n\n")
+}
+
+func (g *generator) method(w *bytes.Buffer, typeName, component, name string, ft *ast.FuncType) {
+	var (
+		params   []string // "name type"
+		args     []string
+		hasCtx   bool
+		variadic bool
+	)
+	i := 0
+	for _, f := range fieldList(ft.Params) {
+		typ := g.expr(f.Type)
+		if _, ok := f.Type.(*ast.Ellipsis); ok {
+			variadic = true
+		}
+		for range namesOrOne(f) {
+			pname := "p" + strconv.Itoa(i)
+			if i == 0 && typ == "context.Context" {
+				pname, hasCtx = "ctx", true
+			}
+			params = append(params, pname+" "+typ)
+			args = append(args, pname)
+			i++
+		}
+	}
+	if variadic {
+		args[len(args)-1] += "..."
+	}
+
+	var results, rnames []string
+	hasErr := false
+	j := 0
+	resFields := fieldList(ft.Results)
+	for k, f := range resFields {
+		typ := g.expr(f.Type)
+		for n := range namesOrOne(f) {
+			rname := "r" + strconv.Itoa(j)
+			if k == len(resFields)-1 && n == len(namesOrOne(f))-1 && typ == "error" {
+				rname, hasErr = "err", true
+			}
+			results = append(results, rname+" "+typ)
+			rnames = append(rnames, rname)
+			j++
+		}
+	}
+
+	fmt.Fprintf(w, "\nfunc (d *%sWithEto) %s(%s)", typeName, name, strings.Join(params, ", "))
+	if len(results) > 0 {
+		fmt.Fprintf(w, " (%s)", strings.Join(results, ", "))
+	}
+	w.WriteString(" {\n")
+
+	spanCtx, ctxArg := "ctx", "ctx"
+	if !hasCtx {
+		spanCtx, ctxArg = "_", "context.Background()"
+	}
+	fmt.Fprintf(w, "\t%s, end := eto.StartCall(%s, %q, %q)\n", spanCtx, ctxArg, component, name)
+	if hasErr {
+		w.WriteString("\tdefer func() { end(err) }()\n")
+	} else {
+		w.WriteString("\tdefer end(nil)\n")
+	}
+
+	call := fmt.Sprintf("d.next.%s(%s)", name, strings.Join(args, ", "))
+	if len(rnames) > 0 {
+		fmt.Fprintf(w, "\t%s = %s\n\treturn %s\n", strings.Join(rnames, ", "), call, strings.Join(rnames, ", "))
+	} else {
+		fmt.Fprintf(w, "\t%s\n", call)
+	}
+	w.WriteString("}\n")
+}
+
+func fieldList(fl *ast.FieldList) []*ast.Field {
+	if fl == nil {
+		return nil
+	}
+	return fl.List
+}
+
+// namesOrOne: field ที่ไม่ได้ตั้งชื่อ (func(int, string)) นับเป็น 1 ตัว
+func namesOrOne(f *ast.Field) []*ast.Ident {
+	if len(f.Names) == 0 {
+		return []*ast.Ident{nil}
+	}
+	return f.Names
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
@@ -2,18 +2,60 @@ package eto
 
 import (
 	"context"
+	"strconv"
+	"sync/atomic"
 	"time"
-	
+
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// AMQPPublishedAtHeader header ที่ ToAMQP ใส่เวลา publish (unix milliseconds)
+// ฝั่ง consumer ใช้คำนวณ amqp_publish_to_consume_ms
+const AMQPPublishedAtHeader = "x-eto-published-at"
+
 // AMQPConsumeHandler รูปแบบ handler ที่รับ ctx + message
 type AMQPConsumeHandler func(ctx context.Context, msg amqp.Delivery) error
 
+// AMQPConsumerOption ปรับพฤติกรรมของ AMQPConsumerInterceptor
+type AMQPConsumerOption func(*amqpConsumerConfig)
+
+type amqpConsumerConfig struct {
+	prefetch int
+}
+
+// WithAMQPPrefetch บอกค่า prefetch (ch.Qos) ของ consumer เพื่อคำนวณ amqp_prefetch_utilization
+// ไม่ใส่ = ไม่ส่ง metric ตัวนี้
+func WithAMQPPrefetch(n int) AMQPConsumerOption {
+	return func(c *amqpConsumerConfig) {
+		if n > 0 {
+			c.prefetch = n
+		}
+	}
+}
+
 // AMQPConsumerInterceptor: wrap handler ให้มี span + metrics อัตโนมัติ
 // ใช้ตอน consume: go func() { for msg := range msgs { wrapper(msg) } }()
-func AMQPConsumerInterceptor(serviceName string, handler AMQPConsumeHandler) func(msg amqp.Delivery) {
+//
+// metrics ที่ได้ (ต่อ queue):
+//   - amqp_consume_total / amqp_consume_duration_ms
+//   - amqp_unacked_messages: message ที่อยู่ใน handler (ยังไม่ ack) ณ ตอนนี้
+//   - amqp_prefetch_utilization: unacked / prefetch (เมื่อใส่ WithAMQPPrefetch)
+//   - amqp_publish_to_consume_ms: เวลารอในคิวจาก publish ถึงเริ่ม consume (จาก AMQPPublishedAtHeader
+//     หรือ Delivery.Timestamp ถ้าไม่มี header) ค่าเดียวกันใส่ที่ span เป็น messaging.queue_time_ms
+func AMQPConsumerInterceptor(serviceName string, handler AMQPConsumeHandler, opts ...AMQPConsumerOption) func(msg amqp.Delivery) {
+	registerInstrumentation("amqp", "github.com/rabbitmq/amqp091-go", nil)
+
+	var cfg amqpConsumerConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	var unacked atomic.Int64
+
 	return func(msg amqp.Delivery) {
 		// start จาก base context (จริง ๆ จะผูกกับ ctx global ของ service ก็ได้)
 		baseCtx := context.Background()
@@ -23,38 +65,108 @@ func AMQPConsumerInterceptor(serviceName string, handler AMQPConsumeHandler) fun
 			FromContext(baseCtx).
 			FromAMQP(msg.Headers)
 
+		p := std()
+		queueTime, hasQueueTime := amqpQueueTime(p, msg)
+		if hasQueueTime {
+			MetricHistogram("amqp_publish_to_consume_ms").
+				Attr("service", serviceName).
+				Attr("queue", msg.RoutingKey).
+				Record(ctx, durationMs(queueTime))
+		}
+
+		cfg.recordInFlight(ctx, p, serviceName, msg.RoutingKey, unacked.Add(1))
+		defer func() {
+			cfg.recordInFlight(ctx, p, serviceName, msg.RoutingKey, unacked.Add(-1))
+		}()
+
 		// เริ่ม span consumer
-		_ = Trace().
+		b := Trace().
 			Name("amqp.consume").
 			FromContext(ctx).
 			Kind(trace.SpanKindConsumer).
 			Attr("amqp.queue", msg.RoutingKey).
-			Attr("amqp.exchange", msg.Exchange).
-			Run(func(ctx context.Context) error {
-				start := time.Now()
-
-				err := handler(ctx, msg)
-
-				// metrics: นับ consume + latency
-				status := "success"
-				if err != nil {
-					status = "error"
-				}
-
-				MetricCounter("amqp_consume_total").
-					Attr("service", serviceName).
-					Attr("queue", msg.RoutingKey).
-					Attr("status", status).
-					Add(ctx, 1)
-
-				latencyMs := float64(time.Since(start).Milliseconds())
-				MetricHistogram("amqp_consume_duration_ms").
-					Attr("service", serviceName).
-					Attr("queue", msg.RoutingKey).
-					Attr("status", status).
-					Record(ctx, latencyMs)
-
-				return err
-			})
+			Attr("amqp.exchange", msg.Exchange)
+		if hasQueueTime {
+			// เวลารอในคิวแยกจากเวลาประมวลผล (duration ของ span เอง)
+			b.Attr("messaging.queue_time_ms", durationMs(queueTime))
+		}
+		_ = b.Run(func(ctx context.Context) error {
+			start := time.Now()
+
+			err := handler(ctx, msg)
+
+			// metrics: นับ consume + latency
+			status := "success"
+			if err != nil {
+				status = "error"
+			}
+
+			MetricCounter("amqp_consume_total").
+				Attr("service", serviceName).
+				Attr("queue", msg.RoutingKey).
+				Attr("status", status).
+				Add(ctx, 1)
+
+			latencyMs := float64(time.Since(start).Milliseconds())
+			MetricHistogram("amqp_consume_duration_ms").
+				Attr("service", serviceName).
+				Attr("queue", msg.RoutingKey).
+				Attr("status", status).
+				Record(ctx, latencyMs)
+
+			return err
+		})
+	}
+}
+
+// recordInFlight ส่งจำนวน message ที่ยังไม่ ack และสัดส่วนต่อ prefetch เป็น gauge
+func (cfg *amqpConsumerConfig) recordInFlight(ctx context.Context, p *Provider, serviceName, queue string, n int64) {
+	if !p.cfg.EnableMetrics || p.meter == nil {
+		return
+	}
+	attrs := p.metricAttrs(ctx, []attribute.KeyValue{
+		attribute.String("service", serviceName),
+		attribute.String("queue", queue),
+	})
+
+	if g := p.getOrCreateGauge("amqp_unacked_messages", "1", "messages delivered to the handler but not finished yet"); g != nil {
+		g.Record(ctx, float64(n), metric.WithAttributes(p.cardinality.apply(p, "amqp_unacked_messages", attrs)...))
+	}
+	if cfg.prefetch > 0 {
+		if g := p.getOrCreateGauge("amqp_prefetch_utilization", "1", "unacked messages / prefetch count"); g != nil {
+			g.Record(ctx, float64(n)/float64(cfg.prefetch), metric.WithAttributes(p.cardinality.apply(p, "amqp_prefetch_utilization", attrs)...))
+		}
+	}
+}
+
+// amqpQueueTime เวลาตั้งแต่ publish ถึงตอนนี้ (นาฬิกา publisher เร็วกว่า → ปัดเป็น 0 ดีกว่าส่งค่าติดลบ)
+func amqpQueueTime(p *Provider, msg amqp.Delivery) (time.Duration, bool) {
+	publishedAt, ok := amqpPublishedAt(msg)
+	if !ok {
+		return 0, false
+	}
+	return max(p.now().Sub(publishedAt), 0), true
+}
+
+// amqpPublishedAt อ่านเวลา publish จาก header ที่ ToAMQP ใส่ไว้ (รองรับชนิดที่ amqp091 decode ได้)
+// ถ้าไม่มี header ใช้ Delivery.Timestamp (ความละเอียดระดับวินาที) แทน
+func amqpPublishedAt(msg amqp.Delivery) (time.Time, bool) {
+	switch v := msg.Headers[AMQPPublishedAtHeader].(type) {
+	case int64:
+		return time.UnixMilli(v), true
+	case int32:
+		return time.UnixMilli(int64(v)), true
+	case int:
+		return time.UnixMilli(int64(v)), true
+	case time.Time:
+		return v, true
+	case string:
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.UnixMilli(ms), true
+		}
+	}
+	if !msg.Timestamp.IsZero() {
+		return msg.Timestamp, true
 	}
+	return time.Time{}, false
 }
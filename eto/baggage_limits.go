@@ -0,0 +1,99 @@
+package eto
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// BaggageLimits จำกัดขนาด baggage ตอน extract (ขาเข้า) และ inject (ส่งต่อ) กัน service ต้นทาง
+// ยัด payload ใหญ่ลง baggage จน header ของ service ปลายทางเกิน limit (0 = ไม่จำกัดในข้อนั้น)
+// entry ที่ไม่ผ่านถูกทิ้ง (เรียงตาม key เก็บตัวที่พอดีก่อน) และนับ eto_baggage_dropped_total{direction}
+type BaggageLimits struct {
+	MaxBytes       int `json:"max_bytes,omitempty"`        // ขนาดรวมของ header baggage
+	MaxMembers     int `json:"max_members,omitempty"`      // จำนวน entry
+	MaxMemberBytes int `json:"max_member_bytes,omitempty"` // ขนาดต่อ entry (key=value;properties)
+}
+
+func (l BaggageLimits) enabled() bool {
+	return l.MaxBytes > 0 || l.MaxMembers > 0 || l.MaxMemberBytes > 0
+}
+
+// apply คืน baggage ที่อยู่ใน limit และจำนวน entry ที่ถูกทิ้ง
+func (l BaggageLimits) apply(bag baggage.Baggage) (baggage.Baggage, int) {
+	members := bag.Members()
+	sort.Slice(members, func(i, j int) bool { return members[i].Key() < members[j].Key() })
+
+	kept := make([]baggage.Member, 0, len(members))
+	size := 0
+	for _, m := range members {
+		n := len(m.String())
+		if l.MaxMemberBytes > 0 && n > l.MaxMemberBytes {
+			continue
+		}
+		if l.MaxMembers > 0 && len(kept) >= l.MaxMembers {
+			continue
+		}
+		sep := 0
+		if len(kept) > 0 {
+			sep = 1 // ","
+		}
+		if l.MaxBytes > 0 && size+sep+n > l.MaxBytes {
+			continue
+		}
+		kept = append(kept, m)
+		size += sep + n
+	}
+	dropped := len(members) - len(kept)
+	if dropped == 0 {
+		return bag, 0
+	}
+	out, err := baggage.New(kept...)
+	if err != nil {
+		return baggage.Baggage{}, len(members)
+	}
+	return out, dropped
+}
+
+// limitedBaggage ห่อ propagation.Baggage ด้วย Config.BaggageLimits
+type limitedBaggage struct {
+	propagation.Baggage
+	pv     *Provider
+	limits BaggageLimits
+}
+
+func (b limitedBaggage) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	ctx = b.Baggage.Extract(ctx, carrier)
+	bag := baggage.FromContext(ctx)
+	if bag.Len() == 0 {
+		// header ใหญ่เกินที่ SDK parse ได้ (8192 bytes / 180 entries) ถูกทิ้งทั้งก้อนโดยไม่มี error
+		if raw := carrier.Get("baggage"); raw != "" {
+			b.pv.countBaggageDropped(ctx, "extract", strings.Count(raw, ",")+1)
+		}
+		return ctx
+	}
+	trimmed, dropped := b.limits.apply(bag)
+	if dropped == 0 {
+		return ctx
+	}
+	b.pv.countBaggageDropped(ctx, "extract", dropped)
+	return baggage.ContextWithBaggage(ctx, trimmed)
+}
+
+func (b limitedBaggage) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	if trimmed, dropped := b.limits.apply(baggage.FromContext(ctx)); dropped > 0 {
+		b.pv.countBaggageDropped(ctx, "inject", dropped)
+		ctx = baggage.ContextWithBaggage(ctx, trimmed)
+	}
+	b.Baggage.Inject(ctx, carrier)
+}
+
+func (p *Provider) countBaggageDropped(ctx context.Context, direction string, n int) {
+	p.MetricCounter("eto_baggage_dropped_total").
+		Description("baggage entries dropped because they exceeded BaggageLimits").
+		Attr("direction", direction).
+		Add(ctx, int64(n))
+}
@@ -0,0 +1,115 @@
+package eto
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Checkpoint: บันทึกความคืบหน้าของงาน batch ที่รันนาน (เป็นชั่วโมง) ให้ดูได้ว่างานค้างอยู่ตรงไหน
+//   - ใส่ span event "checkpoint" (batch.job + progress ทุกตัว) ที่ span ใน ctx
+//   - ค่าที่เป็นตัวเลขใน progress ถูกส่งเป็น gauge batch_progress{job, key}
+//
+// เรียกเป็นระยะ ๆ ระหว่างทำงาน เช่น
+//
+//	eto.Checkpoint(ctx, "settlement", map[string]any{"rows_done": done, "rows_total": total, "cursor": lastID})
+func Checkpoint(ctx context.Context, name string, progress map[string]any) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if name == "" {
+		name = "unnamed-job"
+	}
+
+	// sort key ให้ลำดับ attribute คงที่ระหว่าง checkpoint
+	keys := make([]string, 0, len(progress))
+	for k := range progress {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]attribute.KeyValue, 0, 1+len(keys))
+	attrs = append(attrs, attribute.String("batch.job", name))
+	for _, k := range keys {
+		attrs = append(attrs, anyToAttr("batch.progress."+k, progress[k]))
+	}
+	trace.SpanFromContext(ctx).AddEvent("checkpoint", trace.WithAttributes(attrs...))
+
+	p := std()
+	if !p.cfg.EnableMetrics || p.meter == nil {
+		return
+	}
+	g := p.getOrCreateGauge("batch_progress", "1", "latest progress value reported by eto.Checkpoint")
+	if g == nil {
+		return
+	}
+	for _, k := range keys {
+		v, ok := toFloat64(progress[k])
+		if !ok {
+			continue
+		}
+		attrs := p.metricAttrs(ctx, []attribute.KeyValue{
+			attribute.String("job", name),
+			attribute.String("key", k),
+		})
+		g.Record(ctx, v, metric.WithAttributes(p.cardinality.apply(p, "batch_progress", attrs)...))
+	}
+}
+
+// Chunk: รัน fn ภายใต้ child span ของ chunk ที่ index (1 span ต่อ chunk ไม่ใช่ต่อ row)
+// error จาก fn ถูก record ลง span และคืนกลับให้ caller ตามปกติ
+//
+//	for i, ids := range chunks {
+//	    if err := eto.Chunk(ctx, "settlement", i, func(ctx context.Context) error { return settle(ctx, ids) }); err != nil { ... }
+//	    eto.Checkpoint(ctx, "settlement", map[string]any{"chunks_done": i + 1})
+//	}
+func Chunk(ctx context.Context, job string, index int, fn func(ctx context.Context) error) error {
+	if fn == nil {
+		return nil
+	}
+	if job == "" {
+		job = "unnamed-job"
+	}
+
+	ctx, span := Trace().
+		Name(job+".chunk").
+		FromContext(ctx).
+		Attr("batch.job", job).
+		Attr("batch.chunk.index", index).
+		Start()
+	defer func() { span.End(std().spanEndOptions()...) }()
+
+	err := fn(ctx)
+	if err != nil {
+		std().recordError(span, err)
+		span.SetStatus(codes.Error, std().statusDescription(err))
+	}
+	return err
+}
+
+func toFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,128 @@
+package eto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+// Allocation budgets (go test -bench . -benchmem ./eto) — ถ้าเกินนี้ให้ดูว่า regression มาจากไหนก่อน merge
+//
+//	BenchmarkGinMiddleware           ≤ 75 allocs/op (span + 2 metrics + propagation)
+//	BenchmarkGinMiddlewareSkipRoute  ≤ 10 allocs/op (fast path: มีแค่ของ gin/httptest เอง)
+//	BenchmarkLogSend                 ≤ 30 allocs/op (otel record + zap + caller lookup)
+//	BenchmarkCounterAdd              ≤ 8 allocs/op
+//	BenchmarkTracerLookup/cached     0 allocs/op, เร็วกว่า /sdk (lock ใน TracerProvider ทุกครั้ง) ~3-4 เท่าเมื่อรันขนาน
+
+// setupBench ติดตั้ง provider แบบ in-memory (ไม่ต่อ collector) เป็น default แล้วคืนตัวเดิมตอนจบ
+func setupBench(b *testing.B) {
+	b.Helper()
+
+	p := newProvider(Config{ServiceName: "bench", Environment: "test", EnableMetrics: true})
+	p.tp = sdktrace.NewTracerProvider()
+	p.mp = sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	p.meter = p.mp.Meter("eto")
+	p.otelLogger = sdklog.NewLoggerProvider().Logger("eto")
+	p.logger = zap.NewNop()
+	p.propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	p.initialized = true
+
+	prev := defaultProvider.Swap(p)
+	b.Cleanup(func() {
+		_ = p.Shutdown(context.Background())
+		defaultProvider.Store(prev)
+	})
+}
+
+func benchRouter(opts ...GinOption) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(GinMiddleware(opts...))
+	r.GET("/hello", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func BenchmarkGinMiddleware(b *testing.B) {
+	setupBench(b)
+	r := benchRouter()
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
+
+func BenchmarkGinMiddlewareSkipRoute(b *testing.B) {
+	setupBench(b)
+	r := benchRouter(WithSkipRoutes("/healthz"))
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
+
+func BenchmarkLogSend(b *testing.B) {
+	setupBench(b)
+	ctx, span := Trace().Name("bench").Start()
+	defer span.End()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Log().
+			FromContext(ctx).
+			Info().
+			Msg("bench").
+			Field("i", i).
+			Send()
+	}
+}
+
+func BenchmarkCounterAdd(b *testing.B) {
+	setupBench(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MetricCounter("bench_total").
+			Attr("route", "/hello").
+			Add(ctx, 1)
+	}
+}
+
+func BenchmarkTracerLookup(b *testing.B) {
+	setupBench(b)
+	p := std()
+
+	b.Run("sdk", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = p.tp.Tracer("eto")
+			}
+		})
+	})
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = p.tracer("eto")
+			}
+		})
+	})
+}
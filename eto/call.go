@@ -0,0 +1,45 @@
+package eto
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// StartCall เปิด span "<component>.<method>" สำหรับ decorator ที่สร้างด้วย cmd/etogen (เขียนมือก็ได้)
+// คืน ctx ที่มี span และ end ที่ต้องเรียกตอน method จบพร้อม error ที่คืน:
+// ได้ span error + histogram method_duration_ms{component, method, status}
+//
+//	ctx, end := eto.StartCall(ctx, "UserRepository", "Get")
+//	u, err := next.Get(ctx, id)
+//	end(err)
+func StartCall(ctx context.Context, component, method string) (context.Context, func(err error)) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	p := std()
+	start := time.Now()
+	ctx, span := p.Trace().
+		Name(component+"."+method).
+		FromContext(ctx).
+		Attr("code.namespace", component).
+		Attr("code.function", method).
+		Start()
+
+	return ctx, func(err error) {
+		status := "success"
+		if err != nil {
+			status = "error"
+			p.recordError(span, err)
+			span.SetStatus(codes.Error, p.statusDescription(err))
+		}
+		span.End(p.spanEndOptions()...)
+
+		p.MetricHistogram("method_duration_ms").
+			Attr("component", component).
+			Attr("method", method).
+			Attr("status", status).
+			Record(ctx, durationMs(time.Since(start)))
+	}
+}
@@ -0,0 +1,85 @@
+package eto
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// overflowAttrValue ค่าที่ใช้แทนเมื่อ attribute key มีค่าเกิน Config.MetricAttrLimit
+const overflowAttrValue = "overflow"
+
+// cardinalityGuard จำค่าที่เคยเห็นของแต่ละ (instrument, attribute key)
+type cardinalityGuard struct {
+	limit int
+
+	mu   sync.Mutex
+	seen map[string]map[attribute.Key]map[attribute.Value]struct{}
+}
+
+func newCardinalityGuard(limit int) *cardinalityGuard {
+	if limit <= 0 {
+		return nil
+	}
+	return &cardinalityGuard{
+		limit: limit,
+		seen:  map[string]map[attribute.Key]map[attribute.Value]struct{}{},
+	}
+}
+
+// apply แทนค่าที่เกิน limit ด้วย "overflow" (ค่าที่เคยเห็นแล้วผ่านเสมอ) คืน slice ใหม่ถ้ามีการแทน
+func (g *cardinalityGuard) apply(p *Provider, instrument string, attrs []attribute.KeyValue) []attribute.KeyValue {
+	if g == nil || len(attrs) == 0 {
+		return attrs
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	keys := g.seen[instrument]
+	if keys == nil {
+		keys = map[attribute.Key]map[attribute.Value]struct{}{}
+		g.seen[instrument] = keys
+	}
+
+	var out []attribute.KeyValue
+	for i, kv := range attrs {
+		vals := keys[kv.Key]
+		if vals == nil {
+			vals = map[attribute.Value]struct{}{}
+			keys[kv.Key] = vals
+		}
+		if _, ok := vals[kv.Value]; ok {
+			continue
+		}
+		if len(vals) < g.limit {
+			vals[kv.Value] = struct{}{}
+			continue
+		}
+
+		if out == nil {
+			out = append([]attribute.KeyValue(nil), attrs...)
+		}
+		out[i] = attribute.String(string(kv.Key), overflowAttrValue)
+		p.warnCardinalityOverflow(instrument, kv.Key, g.limit)
+	}
+	if out == nil {
+		return attrs
+	}
+	return out
+}
+
+func (p *Provider) warnCardinalityOverflow(instrument string, key attribute.Key, limit int) {
+	if _, warned := p.metricWarned.LoadOrStore("cardinality\x00"+instrument+"\x00"+string(key), struct{}{}); warned {
+		return
+	}
+	if p.logger == nil {
+		return
+	}
+	p.logger.Warn("eto: metric attribute cardinality limit reached, recording further values as \"overflow\"",
+		zap.String("metric", instrument),
+		zap.String("attribute", string(key)),
+		zap.Int("limit", limit),
+	)
+}
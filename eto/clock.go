@@ -0,0 +1,64 @@
+package eto
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MonotonicClock คืนนาฬิกาที่ยึดเวลา wall clock ตอนสร้างไว้ แล้วเดินต่อด้วย monotonic clock
+// เวลาที่ได้จึงไม่กระโดดตาม NTP (log กับ span เรียงลำดับถูกใน backend) แต่อาจคลาดจาก wall clock
+// ได้เท่ากับที่ NTP ปรับไป ใช้แบบ: eto.Config{ClockSource: eto.MonotonicClock()}
+func MonotonicClock() func() time.Time {
+	anchor := time.Now()
+	return func() time.Time {
+		// anchor มี monotonic reading → time.Since ไม่โดน wall clock jump
+		return anchor.Add(time.Since(anchor))
+	}
+}
+
+// FixedClock นาฬิกาที่หยุดไว้ที่ t สำหรับ test ที่เทียบผลกับ golden file
+func FixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+// SteppingClock นาฬิกาสำหรับ test: เริ่มที่ start แล้วเดินทีละ step ทุกครั้งที่ถูกอ่าน
+// span ที่ปิดผ่าน Run / StartScope จึงมี duration คงที่ (ไม่เป็น 0 เหมือน FixedClock)
+func SteppingClock(start time.Time, step time.Duration) func() time.Time {
+	var mu sync.Mutex
+	next := start
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		t := next
+		next = next.Add(step)
+		return t
+	}
+}
+
+// EndSpan ปิด span ด้วยเวลาจาก Config.ClockSource ของ default provider (ไม่ตั้ง = เหมือน span.End())
+// ใช้แทน span.End() กับ span จาก Trace().Start() เมื่อตั้ง ClockSource เพื่อให้ duration มาจากนาฬิกาเดียวกัน
+//
+//	ctx, span := eto.Trace().Name("job").FromContext(ctx).Start()
+//	defer eto.EndSpan(span)
+func EndSpan(span trace.Span) {
+	span.End(std().spanEndOptions()...)
+}
+
+// spanEndOptions: เมื่อใช้ ClockSource ให้เวลาจบของ span มาจากนาฬิกาเดียวกับเวลาเริ่ม
+// ต้องเรียกตอน span จบจริง (ใน defer ให้ห่อด้วย func) ไม่อย่างนั้นเวลาจบจะเป็นเวลาที่ประกาศ defer
+func (p *Provider) spanEndOptions() []trace.SpanEndOption {
+	if p == nil || p.cfg.ClockSource == nil {
+		return nil
+	}
+	return []trace.SpanEndOption{trace.WithTimestamp(p.now())}
+}
+
+// now คืนเวลาตาม Config.ClockSource (nil = time.Now)
+func (p *Provider) now() time.Time {
+	if p.cfg.ClockSource != nil {
+		return p.cfg.ClockSource()
+	}
+	return time.Now()
+}
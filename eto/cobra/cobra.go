@@ -0,0 +1,128 @@
+// Package cobra ต่อ CLI ที่เขียนด้วย spf13/cobra เข้ากับ pipeline ของ eto
+// ทุก command ที่ถูกเรียกจะได้ root span ชื่อตาม command path (เช่น "tool user sync")
+// พร้อม flag ที่ผู้ใช้ตั้ง (ซ่อนค่าของ flag ที่ดูเป็นความลับ) และ flush telemetry ให้ก่อนโปรแกรมจบ
+//
+// ใช้แบบ:
+//
+//	shutdown, _ := eto.Init(ctx, cfg)
+//	defer shutdown(context.Background())
+//	etocobra.Instrument(rootCmd)
+//	_ = rootCmd.ExecuteContext(ctx)
+package cobra
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Maximumsoft-Co-LTD/otelgo/eto"
+	spfcobra "github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const redacted = "[REDACTED]"
+
+// defaultSecretFlagParts ชื่อ flag ที่มีคำเหล่านี้จะไม่ถูกบันทึกค่า
+var defaultSecretFlagParts = []string{"password", "passwd", "secret", "token", "key", "credential", "auth"}
+
+// Option ปรับพฤติกรรมของ Instrument
+type Option func(*config)
+
+type config struct {
+	redact       map[string]struct{}
+	flushTimeout time.Duration
+}
+
+// WithRedactedFlags ซ่อนค่าของ flag เหล่านี้เพิ่มจากค่าเริ่มต้น (ชื่อที่มี password / token / secret / key ...)
+func WithRedactedFlags(names ...string) Option {
+	return func(c *config) {
+		for _, n := range names {
+			c.redact[n] = struct{}{}
+		}
+	}
+}
+
+// WithFlushTimeout เวลาสูงสุดที่รอ eto.Flush หลัง command จบ (ค่าเริ่มต้น 5 วินาที)
+func WithFlushTimeout(d time.Duration) Option {
+	return func(c *config) {
+		if d > 0 {
+			c.flushTimeout = d
+		}
+	}
+}
+
+// Instrument ห่อ Run / RunE ของ root และ sub-command ทุกตัว (เรียกหลังเพิ่ม sub-command ครบแล้ว)
+func Instrument(root *spfcobra.Command, opts ...Option) *spfcobra.Command {
+	cfg := config{redact: map[string]struct{}{}, flushTimeout: 5 * time.Second}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	wrap(root, &cfg)
+	return root
+}
+
+func wrap(cmd *spfcobra.Command, cfg *config) {
+	for _, sub := range cmd.Commands() {
+		wrap(sub, cfg)
+	}
+	if cmd.Run == nil && cmd.RunE == nil {
+		return
+	}
+
+	run, runE := cmd.Run, cmd.RunE
+	cmd.Run = nil
+	cmd.RunE = func(c *spfcobra.Command, args []string) error {
+		ctx := c.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		b := eto.Trace().
+			Name(c.CommandPath()).
+			FromContext(ctx).
+			Kind(trace.SpanKindInternal).
+			Attr("cli.command", c.CommandPath()).
+			Attr("cli.args.count", len(args))
+		c.Flags().Visit(func(f *pflag.Flag) {
+			b.Attr("cli.flag."+f.Name, cfg.flagValue(f))
+		})
+
+		err := b.Run(func(ctx context.Context) error {
+			c.SetContext(ctx)
+			if runE != nil {
+				return runE(c, args)
+			}
+			run(c, args)
+			return nil
+		})
+
+		cfg.flush()
+		return err
+	}
+}
+
+func (cfg *config) flagValue(f *pflag.Flag) string {
+	if _, ok := cfg.redact[f.Name]; ok {
+		return redacted
+	}
+	name := strings.ToLower(f.Name)
+	for _, part := range defaultSecretFlagParts {
+		if strings.Contains(name, part) {
+			return redacted
+		}
+	}
+	return f.Value.String()
+}
+
+// flush ส่ง telemetry ที่ค้างก่อน process จบ (CLI มักจบเร็วกว่า batch interval)
+func (cfg *config) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.flushTimeout)
+	defer cancel()
+	if err := eto.Flush(ctx); err != nil && !errors.Is(err, eto.ErrNotInitialized) {
+		eto.Log().Warn().Msg("eto/cobra: flush telemetry failed").Field("error", err.Error()).Send()
+	}
+}
@@ -0,0 +1,100 @@
+package eto
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+)
+
+const defaultOTLPGRPCPort = "4317"
+
+// SuggestCollectorConfig: สร้าง YAML ของ OTel Collector แบบ minimal ที่ตรงกับ signals ที่ app นี้ส่ง
+// (pipeline ตาม EnableTraces / EnableMetrics / EnableLogs, receiver port ตาม endpoint ของแต่ละ signal)
+// เอาไว้ให้ทีมใหม่ตั้งต้น ใช้แบบ: fmt.Println(eto.SuggestCollectorConfig(cfg))
+func SuggestCollectorConfig(cfg Config) string {
+	type pipeline struct{ signal, port string }
+	var pipelines []pipeline
+	if cfg.tracesEnabled() {
+		pipelines = append(pipelines, pipeline{"traces", endpointPort(cfg.tracesEndpoint())})
+	}
+	if cfg.EnableMetrics && cfg.metricsPush() {
+		pipelines = append(pipelines, pipeline{"metrics", endpointPort(cfg.metricsEndpoint())})
+	}
+	if cfg.logsEnabled() {
+		pipelines = append(pipelines, pipeline{"logs", endpointPort(cfg.logsEndpoint())})
+	}
+
+	// port เดียว = receiver "otlp" ตัวเดียว, หลาย port = receiver ต่อ port ("otlp/<port>")
+	var ports []string
+	for _, pl := range pipelines {
+		if !slices.Contains(ports, pl.port) {
+			ports = append(ports, pl.port)
+		}
+	}
+	if len(ports) == 0 {
+		ports = []string{defaultOTLPGRPCPort}
+	}
+	receiver := func(port string) string {
+		if len(ports) == 1 {
+			return "otlp"
+		}
+		return "otlp/" + port
+	}
+
+	var sb strings.Builder
+
+	if cfg.ServiceName != "" {
+		fmt.Fprintf(&sb, "# suggested by otelgo for service %q (env: %s)\n", cfg.ServiceName, cfg.Environment)
+	}
+
+	sb.WriteString("receivers:\n")
+	for _, port := range ports {
+		fmt.Fprintf(&sb, "  %s:\n", receiver(port))
+		sb.WriteString("    protocols:\n")
+		sb.WriteString("      grpc:\n")
+		fmt.Fprintf(&sb, "        endpoint: 0.0.0.0:%s\n", port)
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("processors:\n")
+	sb.WriteString("  memory_limiter:\n")
+	sb.WriteString("    check_interval: 1s\n")
+	sb.WriteString("    limit_percentage: 80\n")
+	sb.WriteString("    spike_limit_percentage: 20\n")
+	sb.WriteString("  batch: {}\n")
+	sb.WriteString("\n")
+
+	sb.WriteString("exporters:\n")
+	sb.WriteString("  debug:\n")
+	sb.WriteString("    verbosity: basic\n")
+	sb.WriteString("  otlp:\n")
+	sb.WriteString("    endpoint: <backend-host>:4317 # TODO: เปลี่ยนเป็น backend จริง (Tempo / Loki / ...)\n")
+	sb.WriteString("    compression: gzip\n")
+	sb.WriteString("    tls:\n")
+	sb.WriteString("      insecure: true\n")
+	sb.WriteString("\n")
+
+	sb.WriteString("service:\n")
+	if len(pipelines) == 0 {
+		sb.WriteString("  pipelines: {}\n")
+		return sb.String()
+	}
+	sb.WriteString("  pipelines:\n")
+	for _, pl := range pipelines {
+		fmt.Fprintf(&sb, "    %s:\n", pl.signal)
+		fmt.Fprintf(&sb, "      receivers: [%s]\n", receiver(pl.port))
+		sb.WriteString("      processors: [memory_limiter, batch]\n")
+		sb.WriteString("      exporters: [otlp, debug]\n")
+	}
+
+	return sb.String()
+}
+
+// endpointPort คืน port ของ endpoint แบบ host:port (ไม่มี = 4317 ค่าเริ่มต้นของ OTLP gRPC)
+func endpointPort(endpoint string) string {
+	if _, p, err := net.SplitHostPort(endpoint); err == nil && p != "" {
+		return p
+	}
+	return defaultOTLPGRPCPort
+}
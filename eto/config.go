@@ -1,10 +1,268 @@
 package eto
 
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// Config ของ eto ค่าที่ว่างไว้บางตัวเติมจาก env มาตรฐานของ OTel ตอน New / Init:
+// OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES, OTEL_TRACES_SAMPLER(_ARG), OTEL_EXPORTER_OTLP_ENDPOINT,
+// OTEL_EXPORTER_OTLP_{TRACES,METRICS,LOGS}_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS, OTEL_PROPAGATORS
+// และ OTEL_SDK_DISABLED=true ปิด telemetry ทั้งหมด (เหลือแค่ zap log)
 type Config struct {
-	ServiceName     string // ชื่อ service เช่น "service-a"
-	Environment     string // dev / uat / prod
-	OtelEndpoint    string // OTLP gRPC endpoint เช่น "otel-collector:4317"
-	EnableMetrics   bool   // เผื่ออนาคต
+	ServiceName       string // ชื่อ service เช่น "service-a"
+	Environment       string // dev / uat / prod
+	ServiceVersion    string // version / git sha ของ build เช่น "1.4.2"
+	ServiceNamespace  string // กลุ่มของ service เช่น "payment" (service.namespace)
+	ServiceInstanceID string // id ของ instance (เช่น pod name) ไว้แยก replica, ว่าง = UUID ที่สุ่มครั้งเดียวต่อ process
+
+	// ResourceAttributes ติดไปกับทุก signal เช่น {"team": "payment", "region": "ap-southeast-1"}
+	ResourceAttributes map[string]string
+
+	// DetectResources เปิด resource detectors: host, OS, process (pid / runtime), container id
+	// และ k8s pod / namespace / node จาก env (K8S_POD_NAME, K8S_NAMESPACE_NAME, K8S_NODE_NAME ผ่าน downward API)
+	DetectResources bool
+
+	OtelEndpoint  string // OTLP gRPC endpoint เช่น "otel-collector:4317"
+	EnableMetrics bool   // เผื่ออนาคต
+
+	// MetricsExporter วิธีส่ง metrics (ใช้เมื่อ EnableMetrics): MetricsExporterOTLP (ค่าว่าง = นี้) push ไป collector,
+	// MetricsExporterPrometheus เปิดให้ scrape ผ่าน eto.MetricsHandler() หรือ MetricsExporterBoth
+	MetricsExporter string
+	// LogLevel level เริ่มต้นของ eto.Log() ทั้ง zap และ OTEL logs: "debug", "info" (ค่าว่าง = นี้), "warn", "error"
+	// ถ้าไม่ตั้ง (และไม่มี LogDestinations) OTEL logs ยังรับทุก level ตั้งแต่ debug เหมือนเดิม มีแค่ zap ที่เริ่มที่ info
+	// เปลี่ยนตอน runtime ได้ด้วย eto.SetLogLevel / eto.LogLevelHandler()
+	LogLevel string
+	// LogEncoding รูปแบบ log ของ zap ที่ออก console: LogEncodingJSON หรือ LogEncodingConsole (อ่านง่าย มีสี)
+	// ค่าว่าง = console เมื่อ Environment == "dev" นอกนั้น json (ไฟล์ของ LogDestinations เป็น json เสมอ)
+	LogEncoding string
+	// LogOutput เขียน log (JSON) ลงไฟล์เพิ่มจาก stderr / OTLP พร้อมหมุนไฟล์ตามขนาด / อายุ และ / หรือส่งเข้า syslog / journald
+	// เช่น eto.LogFileOutput{Path: "/var/log/app/app.log", Rotation: eto.LogRotation{MaxSizeMB: 100, MaxAgeDays: 30}}
+	LogOutput LogFileOutput
+	// LogSampling sampling ของ eto.Log() ต่อ level กัน loop ที่ log ถี่ ๆ ท่วม collector (มีผลทั้ง zap และ OTEL logs)
+	// เช่น {"debug": {Initial: 10}, "info": {Initial: 100, Thereafter: 100}} level ที่ไม่อยู่ใน map = ไม่ sample
+	LogSampling map[string]LogSampling
+	// LogBaggageKeys baggage ที่ใส่เป็น field ของทุก log (zap + OTEL) เมื่อ ctx มี เช่น {"user.id", "tenant", "enduser.claim.*"}
+	// ("*" ท้าย key = ทุก key ที่ขึ้นต้นด้วย prefix นั้น) ค่าว่าง = ไม่ใส่ เพราะ baggage มาจากนอก service
+	LogBaggageKeys []string
+	// LogsAsSpanEvents log ที่ส่งระหว่างมี span ทำงานอยู่ใน ctx จะถูกใส่เป็น span event "log" ด้วย
+	// (log.severity, log.message + field) ให้เห็น log ใน trace waterfall ของ backend ที่ไม่ผูก log กับ trace
+	LogsAsSpanEvents bool
+	// LogDestinations กำหนดปลายทางของ eto.Log() เองพร้อม level ต่อปลายทาง (ว่าง = console + otel ตาม LogLevel)
+	// เช่น []eto.LogDestination{{Kind: eto.LogDestinationFile, Path: "/var/log/app.json", Level: "debug"},
+	// {Kind: eto.LogDestinationOTEL, Level: "warn"}} ปลายทางที่ไม่อยู่ในรายการจะไม่ได้ log
+	LogDestinations []LogDestination
+	// Experiments เปิดพฤติกรรมใหม่ทีละ service ก่อนเป็นค่าเริ่มต้น เช่น {eto.ExperimentNewSemconv: true}
+	// ทับได้ด้วย env OTELGO_EXPERIMENTS (ดู ExperimentsEnv) เพื่อ rollback โดยไม่ต้อง deploy โค้ดใหม่
+	Experiments map[string]bool
+	// InstrumentCacheSize จำนวน instrument สูงสุดที่ eto cache ไว้ต่อชนิด (counter / histogram / gauge)
+	// เกินแล้วไล่ตัวที่ไม่ได้ใช้นานสุดออก + warn + นับ eto_instrument_evictions_total (0 = 1000)
+	// ไม่ได้จำกัด memory: meter ของ SDK ยังเก็บ instrument ทุกชื่อที่เคยสร้างไว้ ใช้ eviction เป็นสัญญาณว่าชื่อ metric มาจากข้อมูล
+	InstrumentCacheSize int
+	// MetricExportInterval รอบการ push metrics ของ OTLP / exporter เพิ่มเติม (0 = ค่าเริ่มต้นของ SDK 60 วินาที)
+	MetricExportInterval time.Duration
+	// MetricTemporality MetricTemporalityCumulative (ค่าว่าง = นี้) หรือ MetricTemporalityDelta
+	// สำหรับ backend ที่รับเฉพาะ delta (Prometheus scrape เป็น cumulative เสมอ)
+	MetricTemporality string
+
+	// endpoint แยกราย signal (ว่าง = ใช้ OtelEndpoint)
+	TracesEndpoint  string
+	MetricsEndpoint string
+	LogsEndpoint    string
+
+	// MetricAttrLimit จำกัดจำนวนค่าที่ต่างกันของแต่ละ attribute key ต่อ instrument (0 = ไม่จำกัด)
+	// ค่าที่เกินจะถูกบันทึกเป็น "overflow" กัน label อย่าง user id / path ทำ backend ระเบิด
+	MetricAttrLimit int
+	// MetricAttrsFromSpan key ของ attribute ที่ counter / histogram ดึงจาก span ใน ctx มาใส่เอง
+	// เช่น {"http.route", "messaging.destination.name"} ไม่ต้อง Attr("route", ...) ซ้ำทุกที่ (Attr ที่ใส่เองชนะ)
+	MetricAttrsFromSpan []string
+
+	// HistogramBuckets กำหนด bucket boundaries ต่อ histogram (ชื่อ → boundaries) ผ่าน View ตอน Init
+	// เช่น {"http_request_duration_ms": {5, 10, 25, 50, 100, 250, 500, 1000}}
+	HistogramBuckets map[string][]float64
+	// MetricViews View เพิ่มเติมของ MeterProvider เช่น rename / drop attribute / เปลี่ยน aggregation
+	MetricViews []sdkmetric.View
+
+	// Exporters ปลายทางเพิ่มเติม (fan-out) ที่ได้ทุก signal ที่เปิดอยู่เหมือน exporter หลัก
+	// เช่น []eto.ExporterSpec{{Kind: eto.ExporterStdout}} หรือ collector ตัวที่สองระหว่าง migrate
+	// (ไม่ผ่าน tenant routing ของ TenantExporters)
+	Exporters []ExporterSpec
+
+	// Dialer ใช้เปิด TCP connection ของทุก OTLP gRPC exporter แทน dialer ปกติ
+	// เช่น ผูก source interface (net.Dialer{LocalAddr: ...}) บนเครื่องที่มีหลาย network
+	Dialer func(ctx context.Context, addr string) (net.Conn, error)
+	// DialTimeout timeout ของการเปิด connection ไป collector (ใช้เมื่อไม่ได้ใส่ Dialer, 0 = ค่าเริ่มต้นของ gRPC)
+	DialTimeout time.Duration
+	// DialOptions grpc.DialOption เพิ่มเติมของทุก OTLP gRPC connection (ใส่ท้ายสุด ทับค่าที่ eto ตั้งได้)
+	// เช่น grpc.WithResolvers(...) เพื่อคุมการ resolve DNS เอง
+	DialOptions []grpc.DialOption
+
+	// OTLPHeaders ใส่ทุก request ของ trace / metric / log exporter
+	// เช่น {"authorization": "Bearer <token>", "x-scope-orgid": "team-a"}
+	OTLPHeaders map[string]string
+
+	// เปิด / ปิด traces และ logs (nil = เปิด เหมือนเดิม) เช่น EnableLogs: eto.Bool(false)
+	EnableTraces *bool
+	EnableLogs   *bool
+
+	// SkipCallerPkgs / SkipCallerFiles ข้าม frame ของ wrapper ตอนหา caller ของ log (มีค่า = ต้องไล่ stack ทุกครั้ง)
 	SkipCallerPkgs  []string
 	SkipCallerFiles []string
+	// CallerSkip จำนวน frame ที่ข้ามเพิ่มจากจุดเรียก Send เมื่อไม่ได้ใช้ SkipCallerPkgs / SkipCallerFiles
+	// เช่น 1 สำหรับ wrapper ชั้นเดียวที่เรียก eto.Log()...Send() แทน caller จริง
+	CallerSkip int
+	// DisableCaller ไม่ใส่ field caller ใน log เลย (ประหยัด runtime.Callers ใน hot path)
+	DisableCaller bool
+
+	PrincipalClaims []string      // claim ของ Principal ที่อนุญาตให้ส่งต่อใน baggage / ใส่เป็น span attribute
+	MinSpanDuration time.Duration // span (ที่ไม่ error) สั้นกว่านี้จะถูกทิ้ง เช่น time.Millisecond, 0 = ส่งทุก span
+	DebugSpanLeaks  bool          // debug เท่านั้น: log warning + stack เมื่อ span ถูก GC โดยไม่ได้ End()
+	SamplerHook     SamplerHook   // บังคับเก็บ / ทิ้ง span ตาม business rule, nil = parent-based always-on
+
+	// TrustInboundPrincipal ใส่ enduser.id จาก baggage ที่มากับ request ลงทุก span (เปิดเฉพาะเมื่อ caller ทุกตัวเป็น service ภายใน
+	// ที่เชื่อถือได้ ไม่งั้นใครก็ส่ง baggage ปลอม user id มาได้) ปิด = ใส่เฉพาะ principal จาก WithPrincipal ใน process นี้
+	TrustInboundPrincipal bool
+
+	// MaxSpansPerRequest จำนวน span สูงสุดต่อ root span ที่สร้างผ่าน Trace() (รวม root, 0 = ไม่จำกัด)
+	// เกินแล้ว Trace().Start() คืน span เปล่าที่ไม่ถูกส่ง แต่นับไว้ใน eto.spans_suppressed ของ root
+	// และ eto_spans_suppressed_total กัน loop ผิดพลาดสร้าง span เป็นหมื่นใน request เดียว
+	MaxSpansPerRequest int
+
+	// Sampler sampler หลัก (SamplerHook ตัดสินก่อนถ้ามี) nil = ตาม OTEL_TRACES_SAMPLER / _ARG
+	// ถ้าไม่มี env ด้วย = parent-based always-on
+	Sampler sdktrace.Sampler
+
+	// PrioritySpans เปิดคิวด่วนแยกจากคิวหลัก: span ที่ error หรือ SamplerHook คืน SamplingForce
+	// ไปคิวเล็กที่ flush ถี่ ให้ telemetry ของ incident มาถึงเร็วแม้คิวหลักจะค้าง (nil = คิวเดียว)
+	PrioritySpans *SpanLane
+
+	// GCPauseOnServerSpans ใส่ gc.pause_during_request_ms ให้ server span ที่มีรอบ GC ทับช่วง request
+	// (+ histogram gc_pause_during_request_ms) แยก app ช้าออกจาก runtime pause
+	// ใช้กับ span ที่จบผ่าน GinMiddleware / Trace().Run / SpanScope.Done
+	GCPauseOnServerSpans bool
+
+	// SpanProcessors ต่อท้าย processor หลักของ TracerProvider (ลำดับตาม slice) เช่น processor ที่เติม attribute
+	// ใน OnStart หรือส่ง span ไปที่อื่นเพิ่ม — eto เป็นคน Shutdown / ForceFlush ให้พร้อม provider
+	SpanProcessors []sdktrace.SpanProcessor
+
+	// PeerServiceMap host (glob แบบ path.Match) → peer.service ที่ client instrumentation ใส่ให้ span
+	// เช่น {"users.internal": "user-service", "*.payments.svc.cluster.local": "payment"} ใช้วาด service graph
+	PeerServiceMap map[string]string
+
+	// DefaultAttrsByKind attribute ที่ใส่ให้ทุก span ของ kind นั้นตอน Start (attribute ที่ builder ใส่เองทับ key เดียวกัน)
+	// เช่น {trace.SpanKindClient: {attribute.String("peer.team", "platform")}}
+	DefaultAttrsByKind map[trace.SpanKind][]attribute.KeyValue
+
+	// HashAttrKeys attribute / log field ที่ถูกแทนด้วย SHA-256 อัตโนมัติ (span จาก builder, log, metric attrs)
+	// เช่น {"user.email", "user.phone"} ใช้ correlate ได้โดยไม่มีค่าจริงหลุดไป backend (ดู eto.HashAttr)
+	HashAttrKeys []string
+	// HashSalt salt ของ hash ควรกำหนด (เก็บเป็น secret) ไม่งั้น email / เบอร์โทรเดาย้อนจาก hash ได้
+	// ใช้ค่าเดียวกันทุก service เพื่อให้ hash ตรงกันข้าม service
+	HashSalt string
+
+	// StatusDescription แปลง error เป็น span status description และ exception.message ของ event "exception"
+	// (กัน PII / secret หลุดไป backend)
+	// nil = eto.DefaultStatusDescription (ตัด email / token / password และตัดความยาว)
+	StatusDescription func(err error) string
+
+	// ClockSource ใช้ประทับเวลาทั้ง log และ start ของ span ให้มาจากนาฬิกาเดียวกัน
+	// nil = time.Now (เหมือนเดิม), eto.MonotonicClock() = กัน NTP jump, หรือใส่นาฬิกาปลอมใน test
+	ClockSource func() time.Time
+	// IDGenerator สร้าง trace / span id ของ span ใหม่ (nil = สุ่มตามปกติ)
+	// ใน test ใช้ eto.SequentialIDGenerator() + eto.SteppingClock ให้ span ที่ export ออกมาเหมือนเดิมทุกครั้ง
+	IDGenerator sdktrace.IDGenerator
+
+	// Propagators รูปแบบ header ของ trace context ที่อ่าน / เขียน (ค่าว่าง = OTEL_PROPAGATORS หรือ tracecontext + baggage)
+	// เช่น []string{eto.PropagatorTraceContext, eto.PropagatorBaggage, eto.PropagatorXRay} เมื่อเรียก service หลัง Lambda
+	Propagators []string
+	// BaggageLimits จำกัดขนาด / จำนวน entry ของ baggage ตอน extract และ inject (ค่าว่าง = ไม่จำกัดเพิ่มจาก SDK)
+	// เช่น eto.BaggageLimits{MaxBytes: 1024, MaxMembers: 16}
+	BaggageLimits BaggageLimits
+
+	// ExtractLegacyHeaders อ่าน x-trace-id / x-span-id เป็น parent เมื่อ request ไม่มี traceparent
+	// (สำหรับ caller ภายในรุ่นเก่า)
+	ExtractLegacyHeaders bool
+
+	// TenantFromContext ดึง tenant ของ request จาก ctx → ติด tenant.id ให้ทุก span / log / metric
+	// ถ้า tenant อยู่ใน TenantExporters จะ route traces + logs ไป exporter ของ tenant นั้นแทน
+	// (metrics ติดแค่ attribute ไม่ได้ route เพราะ aggregate รวมใน MeterProvider เดียว)
+	TenantFromContext func(ctx context.Context) string
+	TenantExporters   map[string]TenantExporter
+}
+
+// ค่าของ Config.MetricsExporter
+const (
+	MetricsExporterOTLP       = "otlp"
+	MetricsExporterPrometheus = "prometheus"
+	MetricsExporterBoth       = "both"
+)
+
+// ค่าของ Config.MetricTemporality
+const (
+	MetricTemporalityCumulative = "cumulative"
+	MetricTemporalityDelta      = "delta"
+)
+
+// ค่าของ Config.Propagators (ชื่อเดียวกับ OTEL_PROPAGATORS)
+const (
+	PropagatorTraceContext = "tracecontext"
+	PropagatorBaggage      = "baggage"
+	PropagatorXRay         = "xray" // X-Amzn-Trace-Id ของ AWS X-Ray
+	PropagatorNone         = "none" // ไม่อ่าน / เขียน trace context ใน header เลย
+)
+
+// ค่าของ Config.LogEncoding
+const (
+	LogEncodingJSON    = "json"
+	LogEncodingConsole = "console"
+)
+
+// Bool ช่วยสร้าง *bool สำหรับ field อย่าง EnableTraces / EnableLogs
+func Bool(v bool) *bool {
+	return &v
+}
+
+func (c Config) tracesEnabled() bool { return c.EnableTraces == nil || *c.EnableTraces }
+func (c Config) logsEnabled() bool   { return c.EnableLogs == nil || *c.EnableLogs }
+
+func (c Config) logEncoding() string {
+	if c.LogEncoding == "" && c.Environment == "dev" {
+		return LogEncodingConsole
+	}
+	return firstNonEmpty(c.LogEncoding, LogEncodingJSON)
+}
+
+func (c Config) propagators() []string {
+	if len(c.Propagators) > 0 {
+		return c.Propagators
+	}
+	return []string{PropagatorTraceContext, PropagatorBaggage}
+}
+
+func (c Config) metricsPush() bool {
+	return c.MetricsExporter == "" || c.MetricsExporter == MetricsExporterOTLP || c.MetricsExporter == MetricsExporterBoth
+}
+
+func (c Config) metricsPull() bool {
+	return c.MetricsExporter == MetricsExporterPrometheus || c.MetricsExporter == MetricsExporterBoth
+}
+
+func (c Config) tracesEndpoint() string  { return firstNonEmpty(c.TracesEndpoint, c.OtelEndpoint) }
+func (c Config) metricsEndpoint() string { return firstNonEmpty(c.MetricsEndpoint, c.OtelEndpoint) }
+func (c Config) logsEndpoint() string    { return firstNonEmpty(c.LogsEndpoint, c.OtelEndpoint) }
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
 }
@@ -0,0 +1,143 @@
+package eto
+
+import (
+	"sort"
+	"time"
+)
+
+// maskedValue ค่าที่ใช้แทน secret (header / salt) ใน ConfigSnapshot
+const maskedValue = "***"
+
+// ConfigSnapshot สำเนาแบบอ่านอย่างเดียวของ config ที่ provider ใช้จริง (หลังเติมจาก env แล้ว)
+// ค่าที่เป็น secret (ค่า header, HashSalt, key ของ file exporter) ถูก mask ไว้ ใช้ตรวจว่า pod ที่รันอยู่ตั้งค่าอะไร
+type ConfigSnapshot struct {
+	ServiceName        string            `json:"service_name"`
+	Environment        string            `json:"environment,omitempty"`
+	ServiceVersion     string            `json:"service_version,omitempty"`
+	ServiceNamespace   string            `json:"service_namespace,omitempty"`
+	ResourceAttributes map[string]string `json:"resource_attributes,omitempty"`
+
+	TracesEndpoint  string            `json:"traces_endpoint,omitempty"`
+	MetricsEndpoint string            `json:"metrics_endpoint,omitempty"`
+	LogsEndpoint    string            `json:"logs_endpoint,omitempty"`
+	OTLPHeaders     map[string]string `json:"otlp_headers,omitempty"`
+	Exporters       []ExporterInfo    `json:"exporters,omitempty"`
+	Tenants         []string          `json:"tenants,omitempty"`
+	Propagators     []string          `json:"propagators"`
+	BaggageLimits   BaggageLimits     `json:"baggage_limits"`
+
+	MetricsExporter      string        `json:"metrics_exporter,omitempty"`
+	MetricTemporality    string        `json:"metric_temporality,omitempty"`
+	MetricExportInterval time.Duration `json:"metric_export_interval_ns,omitempty"`
+	Sampler              string        `json:"sampler,omitempty"`
+	MinSpanDuration      time.Duration `json:"min_span_duration_ns,omitempty"`
+	MetricAttrsFromSpan  []string      `json:"metric_attrs_from_span,omitempty"`
+
+	LogLevel     string   `json:"log_level"`
+	LogEncoding  string   `json:"log_encoding"`
+	Experiments  []string `json:"experiments,omitempty"`
+	HashAttrKeys []string `json:"hash_attr_keys,omitempty"`
+	HashSalt     string   `json:"hash_salt,omitempty"`
+
+	// สถานะของ provider
+	Initialized    bool `json:"initialized"`
+	SDKDisabled    bool `json:"sdk_disabled"`
+	TracesActive   bool `json:"traces_active"`
+	MetricsActive  bool `json:"metrics_active"`
+	LogsActive     bool `json:"logs_active"`
+	MetricsHandler bool `json:"metrics_handler"`
+}
+
+// ExporterInfo ปลายทางเพิ่มเติมหนึ่งตัวใน ConfigSnapshot
+type ExporterInfo struct {
+	Kind      string            `json:"kind"`
+	Endpoint  string            `json:"endpoint,omitempty"`
+	Path      string            `json:"path,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Encrypted bool              `json:"encrypted,omitempty"`
+}
+
+// CurrentConfig snapshot ของ config และสถานะของ default provider (ดู ConfigSnapshot)
+func CurrentConfig() ConfigSnapshot {
+	return std().CurrentConfig()
+}
+
+// CurrentConfig ของ Provider ตัวนี้ (ดู eto.CurrentConfig)
+func (p *Provider) CurrentConfig() ConfigSnapshot {
+	cfg := p.cfg
+	s := ConfigSnapshot{
+		ServiceName:        cfg.ServiceName,
+		Environment:        cfg.Environment,
+		ServiceVersion:     cfg.ServiceVersion,
+		ServiceNamespace:   cfg.ServiceNamespace,
+		ResourceAttributes: copyStringMap(cfg.ResourceAttributes),
+
+		TracesEndpoint:  cfg.tracesEndpoint(),
+		MetricsEndpoint: cfg.metricsEndpoint(),
+		LogsEndpoint:    cfg.logsEndpoint(),
+		OTLPHeaders:     maskHeaders(cfg.OTLPHeaders),
+		Propagators:     append([]string(nil), cfg.propagators()...),
+		BaggageLimits:   cfg.BaggageLimits,
+
+		MetricsExporter:      firstNonEmpty(cfg.MetricsExporter, MetricsExporterOTLP),
+		MetricTemporality:    firstNonEmpty(cfg.MetricTemporality, MetricTemporalityCumulative),
+		MetricExportInterval: cfg.MetricExportInterval,
+		MinSpanDuration:      cfg.MinSpanDuration,
+		MetricAttrsFromSpan:  append([]string(nil), cfg.MetricAttrsFromSpan...),
+
+		LogLevel:     p.logLevel.Level().String(),
+		LogEncoding:  cfg.logEncoding(),
+		Experiments:  p.enabledExperiments(),
+		HashAttrKeys: append([]string(nil), cfg.HashAttrKeys...),
+
+		Initialized:    p.initialized,
+		SDKDisabled:    p.disabled,
+		TracesActive:   p.tp != nil,
+		MetricsActive:  p.mp != nil,
+		LogsActive:     p.lp != nil,
+		MetricsHandler: p.metricsHandler != nil,
+	}
+	if cfg.HashSalt != "" {
+		s.HashSalt = maskedValue
+	}
+	if cfg.Sampler != nil {
+		s.Sampler = cfg.Sampler.Description()
+	}
+	for _, e := range cfg.Exporters {
+		s.Exporters = append(s.Exporters, ExporterInfo{
+			Kind:      e.kind(),
+			Endpoint:  e.Endpoint,
+			Path:      e.Path,
+			Headers:   maskHeaders(e.Headers),
+			Encrypted: e.EncryptionKey != nil,
+		})
+	}
+	for name := range cfg.TenantExporters {
+		s.Tenants = append(s.Tenants, name)
+	}
+	sort.Strings(s.Tenants)
+	return s
+}
+
+// maskHeaders คง key ไว้ (รู้ว่าตั้ง header อะไร) แต่ซ่อนค่า (token / api key)
+func maskHeaders(h map[string]string) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(h))
+	for k := range h {
+		out[k] = maskedValue
+	}
+	return out
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+package eto
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ContextInfo รวมข้อมูล telemetry ของ ctx ไว้ในที่เดียว (ใช้ทำ error response / audit record)
+type ContextInfo struct {
+	Span    trace.Span
+	TraceID string // "" ถ้า ctx ไม่มี span
+	SpanID  string
+	Sampled bool
+	Baggage baggage.Baggage
+	Logger  *zap.Logger // zap logger ที่ติด trace_id / span_id ไว้แล้ว (ไม่เคย nil)
+
+	ctx context.Context
+}
+
+// FromContext ดึง span / trace id / baggage / logger ของ ctx ออกมาครั้งเดียว
+// ใช้แบบ:
+//
+//	info := eto.FromContext(c.Request.Context())
+//	c.JSON(500, gin.H{"error": "internal error", "trace_id": info.TraceID})
+func FromContext(ctx context.Context) ContextInfo {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	p := std()
+
+	span := trace.SpanFromContext(ctx)
+	sc := span.SpanContext()
+
+	logger := p.logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	info := ContextInfo{
+		Span:    span,
+		Sampled: sc.IsSampled(),
+		Baggage: baggage.FromContext(ctx),
+		ctx:     ctx,
+	}
+	if sc.IsValid() {
+		info.TraceID = sc.TraceID().String()
+		info.SpanID = sc.SpanID().String()
+		logger = logger.With(
+			zap.String("trace_id", info.TraceID),
+			zap.String("span_id", info.SpanID),
+		)
+	}
+	info.Logger = logger
+	return info
+}
+
+// Log เริ่ม LogBuilder ที่ผูกกับ ctx เดียวกัน (ส่งทั้ง OTEL log และ zap เหมือน eto.Log())
+func (i ContextInfo) Log() *LogBuilder {
+	return Log().FromContext(i.ctx)
+}
@@ -0,0 +1,24 @@
+package eto
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+// grpcDialOptions dial option ของทุก OTLP gRPC connection (exporter หลัก, Exporters, TenantExporters)
+// จาก Config.Dialer / DialTimeout / DialOptions (DialOptions ใส่ท้ายสุดจึงทับตัวอื่นได้)
+func (c Config) grpcDialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	switch {
+	case c.Dialer != nil:
+		opts = append(opts, grpc.WithContextDialer(c.Dialer))
+	case c.DialTimeout > 0:
+		d := &net.Dialer{Timeout: c.DialTimeout}
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return d.DialContext(ctx, "tcp", addr)
+		}))
+	}
+	return append(opts, c.DialOptions...)
+}
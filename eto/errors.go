@@ -0,0 +1,107 @@
+package eto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// sentinel errors ให้ caller เช็คด้วย errors.Is ได้
+var (
+	// ErrNotInitialized: ยังไม่ได้เรียก eto.Init (หรือ Init ล้มเหลว)
+	ErrNotInitialized = errors.New("eto: not initialized (call eto.Init first)")
+	// ErrExporterDown: ต่อ collector ไม่ได้ / export ล่าสุดล้มเหลว
+	ErrExporterDown = errors.New("eto: exporter unavailable")
+	// ErrInvalidConfig: Config ที่ส่งให้ Init ไม่ครบหรือไม่ถูกต้อง
+	ErrInvalidConfig = errors.New("eto: invalid config")
+	// ErrSignalDisabled: signal นั้นปิดอยู่ใน Config (เช่น ExportRawLogs ตอน EnableLogs = false)
+	ErrSignalDisabled = errors.New("eto: signal disabled")
+	// ErrInvalidEvent: payload ของ EventDef.Emit / Validate ไม่ตรงกับ EventSchema
+	ErrInvalidEvent = errors.New("eto: invalid event")
+)
+
+// exportErrTTL: export error ที่ไม่เกิดซ้ำเกินช่วงนี้ถือว่าหายแล้ว (error ชั่วคราวไม่ทำให้ Health พังค้าง)
+// ยาวกว่ารอบ export ของ metrics ค่าเริ่มต้น (60s) เพื่อให้ error ที่ยังเป็นอยู่ถูกตั้งซ้ำก่อนหมดอายุ
+const exportErrTTL = 2 * time.Minute
+
+func (p *Provider) setExportErr(err error) {
+	p.healthMu.Lock()
+	p.lastExportErr = err
+	p.lastExportErrAt = time.Now()
+	p.healthMu.Unlock()
+}
+
+func (p *Provider) setInstrumentErr(err error) {
+	p.healthMu.Lock()
+	p.lastInstrumentErr = err
+	p.healthMu.Unlock()
+}
+
+// Health: เช็คสถานะ pipeline ปัจจุบัน
+// คืน ErrNotInitialized ถ้ายังไม่ Init, ErrExporterDown ถ้า export พังภายใน 2 นาทีที่ผ่านมา (และยังไม่มี Flush สำเร็จ)
+// และ error ของการสร้าง metric instrument ล่าสุด (ถ้ามี) ทั้งหมดเช็คด้วย errors.Is ได้
+func Health() error {
+	return std().Health()
+}
+
+// Health ของ Provider ตัวนี้ (ดู eto.Health)
+func (p *Provider) Health() error {
+	if !p.initialized {
+		return ErrNotInitialized
+	}
+
+	p.healthMu.Lock()
+	exportErr, instrumentErr := p.lastExportErr, p.lastInstrumentErr
+	if exportErr != nil && time.Since(p.lastExportErrAt) > exportErrTTL {
+		exportErr = nil
+	}
+	p.healthMu.Unlock()
+
+	var errs []error
+	if exportErr != nil {
+		errs = append(errs, fmt.Errorf("%w: %v", ErrExporterDown, exportErr))
+	}
+	if instrumentErr != nil {
+		errs = append(errs, instrumentErr)
+	}
+	return errors.Join(errs...)
+}
+
+// Flush: บังคับส่ง spans / metrics / logs ที่ค้างใน buffer ออกไปทันที (เช่น ก่อนจบ job)
+func Flush(ctx context.Context) error {
+	return std().Flush(ctx)
+}
+
+// Flush ของ Provider ตัวนี้ (ดู eto.Flush)
+func (p *Provider) Flush(ctx context.Context) error {
+	if !p.initialized {
+		return ErrNotInitialized
+	}
+
+	var errs []error
+	if p.tp != nil {
+		if err := p.tp.ForceFlush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if p.mp != nil {
+		if err := p.mp.ForceFlush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if p.lp != nil {
+		if err := p.lp.ForceFlush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		err := errors.Join(errs...)
+		p.setExportErr(err)
+		return fmt.Errorf("%w: %w", ErrExporterDown, err)
+	}
+
+	p.setExportErr(nil)
+	return nil
+}
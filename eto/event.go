@@ -0,0 +1,229 @@
+package eto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EventFieldType ชนิดของ field ใน EventSchema
+type EventFieldType int
+
+const (
+	EventString EventFieldType = iota
+	EventInt                   // int / int8..int64 / uint..uint64
+	EventFloat                 // float32 / float64 (รับตัวเลขจำนวนเต็มด้วย)
+	EventBool
+)
+
+func (t EventFieldType) String() string {
+	switch t {
+	case EventString:
+		return "string"
+	case EventInt:
+		return "int"
+	case EventFloat:
+		return "float"
+	case EventBool:
+		return "bool"
+	default:
+		return fmt.Sprintf("EventFieldType(%d)", int(t))
+	}
+}
+
+// EventSchema นิยาม custom event หนึ่งตัว (ประกาศครั้งเดียวแล้วใช้ร่วมกันทุกทีม)
+type EventSchema struct {
+	Name     string                    // เช่น "order.placed" (event.name)
+	Version  int                       // ขึ้นเลขเมื่อเปลี่ยน field แบบไม่ compatible (event.schema_version)
+	Required map[string]EventFieldType // field ที่ต้องมี
+	Optional map[string]EventFieldType // field ที่มีหรือไม่มีก็ได้ (มีแล้วชนิดต้องตรง)
+	Strict   bool                      // true = field ที่ไม่ได้ประกาศถือว่าผิด schema
+
+	// FlagInvalid true = payload ที่ผิด schema ยังส่งออกไปพร้อม event.invalid = true / event.invalid_reason
+	// (false = ไม่ส่ง คืน error อย่างเดียว)
+	FlagInvalid bool
+}
+
+// EventDef event ที่นิยามแล้วจาก DefineEvent
+type EventDef struct {
+	schema EventSchema
+}
+
+// DefineEvent นิยาม event จาก schema ใช้เป็นตัวแปรระดับ package:
+//
+//	var OrderPlaced = eto.DefineEvent(eto.EventSchema{
+//		Name:     "order.placed",
+//		Version:  2,
+//		Required: map[string]eto.EventFieldType{"order_id": eto.EventString, "amount": eto.EventFloat},
+//	})
+//
+//	err := OrderPlaced.Emit(ctx, map[string]any{"order_id": id, "amount": 99.5})
+//
+// schema ผิดเอง (ไม่มี Name / field ซ้ำใน Required และ Optional) = panic เพราะเป็นความผิดของโค้ด
+func DefineEvent(schema EventSchema) *EventDef {
+	if schema.Name == "" {
+		panic("eto.DefineEvent: schema.Name is required")
+	}
+	for k := range schema.Optional {
+		if _, dup := schema.Required[k]; dup {
+			panic(fmt.Sprintf("eto.DefineEvent: %s: field %q is both required and optional", schema.Name, k))
+		}
+	}
+	return &EventDef{schema: schema}
+}
+
+// Name ชื่อ event
+func (d *EventDef) Name() string { return d.schema.Name }
+
+// Validate ตรวจ payload กับ schema โดยไม่ส่งอะไรออกไป (ใช้ใน test ได้)
+func (d *EventDef) Validate(payload map[string]any) error {
+	var problems []string
+	for _, k := range sortedKeys(d.schema.Required) {
+		v, ok := payload[k]
+		if !ok || v == nil {
+			problems = append(problems, fmt.Sprintf("missing %q", k))
+			continue
+		}
+		if !eventTypeMatches(d.schema.Required[k], v) {
+			problems = append(problems, fmt.Sprintf("%q: want %s, got %T", k, d.schema.Required[k], v))
+		}
+	}
+	for _, k := range sortedKeys(payload) {
+		if _, ok := d.schema.Required[k]; ok {
+			continue
+		}
+		t, ok := d.schema.Optional[k]
+		switch {
+		case ok && payload[k] != nil && !eventTypeMatches(t, payload[k]):
+			problems = append(problems, fmt.Sprintf("%q: want %s, got %T", k, t, payload[k]))
+		case !ok && d.schema.Strict:
+			problems = append(problems, fmt.Sprintf("unknown field %q", k))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s v%d: %s", ErrInvalidEvent, d.schema.Name, d.schema.Version, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Emit ตรวจ payload แล้วส่ง event ผ่าน default provider: OTEL log record (event.name + field เป็น attribute)
+// และ span event บน span ใน ctx ถ้ามี payload ผิด schema คืน error (errors.Is ErrInvalidEvent)
+// และนับ eto_event_invalid_total{event, version}
+func (d *EventDef) Emit(ctx context.Context, payload map[string]any) error {
+	return d.EmitWith(std(), ctx, payload)
+}
+
+// EmitWith เหมือน Emit แต่ส่งผ่าน Provider ที่กำหนด
+func (d *EventDef) EmitWith(p *Provider, ctx context.Context, payload map[string]any) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	err := d.Validate(payload)
+	if err != nil {
+		p.MetricCounter("eto_event_invalid_total").
+			Description("custom events rejected or flagged by schema validation").
+			Attr("event", d.schema.Name).
+			Attr("version", d.schema.Version).
+			Add(ctx, 1)
+		if !d.schema.FlagInvalid {
+			return err
+		}
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("event.name", d.schema.Name),
+		attribute.Int("event.schema_version", d.schema.Version),
+	}
+	for _, k := range sortedKeys(payload) {
+		if payload[k] != nil {
+			attrs = append(attrs, d.attr(k, payload[k]))
+		}
+	}
+	if err != nil {
+		attrs = append(attrs,
+			attribute.Bool("event.invalid", true),
+			attribute.String("event.invalid_reason", err.Error()),
+		)
+	}
+
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.AddEvent(d.schema.Name, trace.WithAttributes(attrs...))
+	}
+
+	if p.otelLogger != nil {
+		var rec otellog.Record
+		rec.SetEventName(d.schema.Name)
+		rec.SetSeverity(otellog.SeverityInfo)
+		rec.SetSeverityText("INFO")
+		rec.SetBody(otellog.StringValue(d.schema.Name))
+		for _, a := range attrs {
+			rec.AddAttributes(otellog.KeyValueFromAttribute(a))
+		}
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			rec.AddAttributes(
+				otellog.String("trace_id", sc.TraceID().String()),
+				otellog.String("span_id", sc.SpanID().String()),
+			)
+		}
+		now := p.now().UTC()
+		rec.SetTimestamp(now)
+		rec.SetObservedTimestamp(now)
+		p.otelLogger.Emit(ctx, rec)
+	}
+	return err
+}
+
+// attr แปลงค่าตามชนิดใน schema (ตัวเลขทุกขนาดเป็น int64 / float64 ให้ backend query ชนิดเดียว)
+func (d *EventDef) attr(key string, v any) attribute.KeyValue {
+	t, ok := d.schema.Required[key]
+	if !ok {
+		t, ok = d.schema.Optional[key]
+	}
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		if ok && t == EventFloat {
+			return attribute.Float64(key, float64(rv.Int()))
+		}
+		return attribute.Int64(key, rv.Int())
+	case rv.CanUint():
+		if ok && t == EventFloat {
+			return attribute.Float64(key, float64(rv.Uint()))
+		}
+		return attribute.Int64(key, int64(rv.Uint()))
+	case rv.CanFloat():
+		return attribute.Float64(key, rv.Float())
+	}
+	return anyToAttr(key, v)
+}
+
+// eventTypeMatches รับชนิดตัวเลขของ Go ได้หลายขนาด (payload มักมาจาก struct / JSON ที่ไม่ได้ใช้ int64 ตรง ๆ)
+func eventTypeMatches(t EventFieldType, v any) bool {
+	switch v.(type) {
+	case string:
+		return t == EventString
+	case bool:
+		return t == EventBool
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return t == EventInt || t == EventFloat
+	case float32, float64:
+		return t == EventFloat
+	default:
+		return false
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,57 @@
+package eto
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// ExperimentNewSemconv ใช้ชื่อ attribute ของ HTTP semconv รุ่น stable
+// (http.request.method / http.response.status_code แทน http.method / http.status_code)
+const ExperimentNewSemconv = "new_semconv"
+
+// ExperimentsEnv เปิด / ปิด experiment ต่อ service โดยไม่แก้โค้ด ทับค่าใน Config.Experiments
+// เช่น OTELGO_EXPERIMENTS="new_semconv,-some_flag" ("-" นำหน้า = ปิด)
+const ExperimentsEnv = "OTELGO_EXPERIMENTS"
+
+// resolveExperiments รวม Config.Experiments กับ ExperimentsEnv (env ชนะ เพื่อให้ rollback ได้จาก deployment)
+// ชื่อที่ไม่รู้จักเก็บไว้เฉย ๆ: config ที่เขียนไว้สำหรับ otelgo รุ่นใหม่กว่าต้องไม่ทำให้ Init ของรุ่นเก่าพัง
+func resolveExperiments(cfg Config) map[string]bool {
+	out := make(map[string]bool, len(cfg.Experiments))
+	for name, on := range cfg.Experiments {
+		out[name] = on
+	}
+	for _, f := range strings.Split(os.Getenv(ExperimentsEnv), ",") {
+		f = strings.TrimSpace(f)
+		switch {
+		case f == "":
+		case strings.HasPrefix(f, "-"):
+			out[strings.TrimPrefix(f, "-")] = false
+		default:
+			out[strings.TrimPrefix(f, "+")] = true
+		}
+	}
+	return out
+}
+
+// Experiment บอกว่า experiment นี้เปิดอยู่ใน default provider หรือไม่
+func Experiment(name string) bool {
+	return std().Experiment(name)
+}
+
+// Experiment ของ Provider ตัวนี้ (ดู eto.Experiment)
+func (p *Provider) Experiment(name string) bool {
+	return p.experiments[name]
+}
+
+// enabledExperiments รายชื่อที่เปิด (เรียงตามชื่อ) ไว้รายงานใน Instrumentations()
+func (p *Provider) enabledExperiments() []string {
+	var out []string
+	for name, on := range p.experiments {
+		if on {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,161 @@
+package eto
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	otlploggrpc "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	otlpmetricgrpc "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	otlpgrpc "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ค่าของ ExporterSpec.Kind
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// ExporterSpec ปลายทางเพิ่มเติมที่ได้ spans / metrics / logs ชุดเดียวกับ exporter หลัก
+// เช่น collector ตัวใหม่ระหว่าง migrate หรือ stdout ไว้ debug
+type ExporterSpec struct {
+	Kind     string            // ExporterOTLP (ค่าว่าง = นี้), ExporterStdout หรือ ExporterFile
+	Endpoint string            // OTLP gRPC endpoint (Kind = otlp เท่านั้น)
+	Headers  map[string]string // รวมกับ Config.OTLPHeaders (ของ spec ทับ key เดียวกัน)
+
+	// Path directory ที่เก็บไฟล์ (Kind = file เท่านั้น)
+	Path string
+	// EncryptionKey คืน AES key 16 / 24 / 32 bytes (เช่น EncryptionKeyFromEnv หรือ data key จาก KMS)
+	// nil = เขียน plaintext (Kind = file เท่านั้น)
+	EncryptionKey func() ([]byte, error)
+}
+
+func (s ExporterSpec) kind() string {
+	if s.Kind == "" {
+		return ExporterOTLP
+	}
+	return s.Kind
+}
+
+func (s ExporterSpec) validate() error {
+	switch s.kind() {
+	case ExporterOTLP:
+		if s.Endpoint == "" {
+			return fmt.Errorf("%w: Exporters: otlp exporter requires Endpoint", ErrInvalidConfig)
+		}
+	case ExporterStdout:
+	case ExporterFile:
+		if s.Path == "" {
+			return fmt.Errorf("%w: Exporters: file exporter requires Path", ErrInvalidConfig)
+		}
+	default:
+		return fmt.Errorf("%w: Exporters: unknown kind %q", ErrInvalidConfig, s.Kind)
+	}
+	return nil
+}
+
+// exporter เพิ่มเติมไม่ใช้ WithBlock: ปลายทางรองล่มต้องไม่ทำให้ Init ค้าง
+
+func (p *Provider) extraSpanProcessors(ctx context.Context) ([]sdktrace.SpanProcessor, error) {
+	var out []sdktrace.SpanProcessor
+	for _, spec := range p.cfg.Exporters {
+		var (
+			exp sdktrace.SpanExporter
+			err error
+		)
+		switch spec.kind() {
+		case ExporterStdout:
+			exp, err = stdouttrace.New()
+		case ExporterFile:
+			var w io.Writer
+			if w, err = p.openExportFile(spec, "traces"); err == nil {
+				exp, err = stdouttrace.New(stdouttrace.WithWriter(w))
+			}
+		default:
+			exp, err = otlpgrpc.New(
+				ctx,
+				otlpgrpc.WithEndpoint(spec.Endpoint),
+				otlpgrpc.WithInsecure(),
+				otlpgrpc.WithDialOption(p.cfg.grpcDialOptions()...),
+				otlpgrpc.WithHeaders(mergeHeaders(p.cfg.OTLPHeaders, spec.Headers)),
+			)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: trace exporter %s %s: %w", ErrExporterDown, spec.kind(), spec.Endpoint, err)
+		}
+		out = append(out, newMinDurationProcessor(sdktrace.NewBatchSpanProcessor(exp), p.cfg.MinSpanDuration))
+	}
+	return out, nil
+}
+
+func (p *Provider) extraMetricReaders(ctx context.Context) ([]sdkmetric.Reader, error) {
+	var out []sdkmetric.Reader
+	for _, spec := range p.cfg.Exporters {
+		var (
+			exp sdkmetric.Exporter
+			err error
+		)
+		switch spec.kind() {
+		case ExporterStdout:
+			exp, err = stdoutmetric.New(stdoutmetric.WithTemporalitySelector(metricTemporality(p.cfg)))
+		case ExporterFile:
+			var w io.Writer
+			if w, err = p.openExportFile(spec, "metrics"); err == nil {
+				exp, err = stdoutmetric.New(stdoutmetric.WithWriter(w), stdoutmetric.WithTemporalitySelector(metricTemporality(p.cfg)))
+			}
+		default:
+			exp, err = otlpmetricgrpc.New(
+				ctx,
+				otlpmetricgrpc.WithEndpoint(spec.Endpoint),
+				otlpmetricgrpc.WithInsecure(),
+				otlpmetricgrpc.WithDialOption(p.cfg.grpcDialOptions()...),
+				otlpmetricgrpc.WithHeaders(mergeHeaders(p.cfg.OTLPHeaders, spec.Headers)),
+				otlpmetricgrpc.WithTemporalitySelector(metricTemporality(p.cfg)),
+			)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: metric exporter %s %s: %w", ErrExporterDown, spec.kind(), spec.Endpoint, err)
+		}
+		out = append(out, newPeriodicReader(p.cfg, exp))
+	}
+	return out, nil
+}
+
+func (p *Provider) extraLogProcessors(ctx context.Context) ([]sdklog.Processor, error) {
+	var out []sdklog.Processor
+	for _, spec := range p.cfg.Exporters {
+		var (
+			exp sdklog.Exporter
+			err error
+		)
+		switch spec.kind() {
+		case ExporterStdout:
+			exp, err = stdoutlog.New()
+		case ExporterFile:
+			var w io.Writer
+			if w, err = p.openExportFile(spec, "logs"); err == nil {
+				exp, err = stdoutlog.New(stdoutlog.WithWriter(w))
+			}
+		default:
+			exp, err = otlploggrpc.New(
+				ctx,
+				otlploggrpc.WithEndpoint(spec.Endpoint),
+				otlploggrpc.WithInsecure(),
+				otlploggrpc.WithDialOption(p.cfg.grpcDialOptions()...),
+				otlploggrpc.WithHeaders(mergeHeaders(p.cfg.OTLPHeaders, spec.Headers)),
+			)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: log exporter %s %s: %w", ErrExporterDown, spec.kind(), spec.Endpoint, err)
+		}
+		out = append(out, sdklog.NewBatchProcessor(exp))
+	}
+	return out, nil
+}
@@ -0,0 +1,158 @@
+package eto
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ExporterFile เขียน telemetry เป็น JSON ลงไฟล์ใน ExporterSpec.Path (traces.jsonl / metrics.jsonl / logs.jsonl)
+// ใส่ ExporterSpec.EncryptionKey เพื่อเข้ารหัสด้วย AES-GCM ก่อนลง disk (อ่านกลับด้วย NewExportDecryptReader)
+
+// maxSealedFrame กันไฟล์เสีย / key ผิด แล้วไปจอง memory ตามค่าความยาวขยะ
+const maxSealedFrame = 64 << 20
+
+var errBadSealedFrame = errors.New("eto: invalid encrypted export frame")
+
+// EncryptionKeyFromEnv อ่าน AES key (base64, 16 / 24 / 32 bytes) จาก env ชื่อ name ตอน Init
+// ถ้าเก็บ key ใน KMS ให้เขียน func ของตัวเองที่ decrypt data key แล้วคืน bytes แทน
+func EncryptionKeyFromEnv(name string) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		v := strings.TrimSpace(os.Getenv(name))
+		if v == "" {
+			return nil, fmt.Errorf("env %s is empty", name)
+		}
+		key, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", name, err)
+		}
+		return key, nil
+	}
+}
+
+// openExportFile เปิดไฟล์ของ signal (append, 0600) และห่อด้วย sealedWriter เมื่อมี EncryptionKey
+// ไฟล์ถูกปิดตอน Provider.Shutdown
+func (p *Provider) openExportFile(spec ExporterSpec, signal string) (io.Writer, error) {
+	var aead cipher.AEAD
+	if spec.EncryptionKey != nil {
+		key, err := spec.EncryptionKey()
+		if err != nil {
+			return nil, fmt.Errorf("%w: Exporters: file encryption key: %w", ErrInvalidConfig, err)
+		}
+		if aead, err = newAEAD(key); err != nil {
+			return nil, fmt.Errorf("%w: Exporters: file encryption key: %w", ErrInvalidConfig, err)
+		}
+	}
+
+	if err := os.MkdirAll(spec.Path, 0o700); err != nil {
+		return nil, fmt.Errorf("%w: file exporter %s: %w", ErrExporterDown, spec.Path, err)
+	}
+	f, err := os.OpenFile(filepath.Join(spec.Path, signal+".jsonl"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("%w: file exporter %s: %w", ErrExporterDown, spec.Path, err)
+	}
+	p.closers = append(p.closers, f)
+
+	if aead == nil {
+		return f, nil
+	}
+	return &sealedWriter{w: f, aead: aead}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealedWriter เข้ารหัสทุก Write เป็น 1 frame: [len uint32 big-endian][nonce][ciphertext+tag]
+// exporter แบบ stdout เขียน 1 JSON record ต่อ Write จึงได้ 1 frame ต่อ record
+type sealedWriter struct {
+	mu   sync.Mutex
+	w    io.Writer
+	aead cipher.AEAD
+}
+
+func (s *sealedWriter) Write(b []byte) (int, error) {
+	nonceSize := s.aead.NonceSize()
+	frame := make([]byte, 4+nonceSize, 4+nonceSize+len(b)+s.aead.Overhead())
+	if _, err := rand.Read(frame[4 : 4+nonceSize]); err != nil {
+		return 0, err
+	}
+	frame = s.aead.Seal(frame, frame[4:4+nonceSize], b, nil)
+	binary.BigEndian.PutUint32(frame[:4], uint32(len(frame)-4))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(frame); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// NewExportDecryptReader อ่านไฟล์ที่ ExporterFile เข้ารหัสไว้ คืน reader ของ JSON เดิม
+//
+//	f, _ := os.Open("/var/lib/app/telemetry/traces.jsonl")
+//	r, _ := eto.NewExportDecryptReader(f, key)
+//	_, err := io.Copy(os.Stdout, r)
+func NewExportDecryptReader(r io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &openReader{r: bufio.NewReader(r), aead: aead}, nil
+}
+
+type openReader struct {
+	r    *bufio.Reader
+	aead cipher.AEAD
+	buf  []byte // plaintext ของ frame ปัจจุบันที่ยังอ่านไม่หมด
+}
+
+func (o *openReader) Read(b []byte) (int, error) {
+	for len(o.buf) == 0 {
+		if err := o.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(b, o.buf)
+	o.buf = o.buf[n:]
+	return n, nil
+}
+
+func (o *openReader) next() error {
+	var hdr [4]byte
+	if _, err := io.ReadFull(o.r, hdr[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("eto: truncated encrypted export: %w", err)
+		}
+		return err
+	}
+	size := binary.BigEndian.Uint32(hdr[:])
+	if size > maxSealedFrame || int(size) < o.aead.NonceSize() {
+		return errBadSealedFrame
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(o.r, frame); err != nil {
+		return fmt.Errorf("eto: truncated encrypted export: %w", err)
+	}
+	nonce, ct := frame[:o.aead.NonceSize()], frame[o.aead.NonceSize():]
+	plain, err := o.aead.Open(ct[:0], nonce, ct, nil)
+	if err != nil {
+		return fmt.Errorf("eto: decrypt export frame: %w", err)
+	}
+	o.buf = plain
+	return nil
+}
@@ -0,0 +1,132 @@
+package eto
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const gcPauseMetric = "/sched/pauses/total/gc:seconds"
+
+// gcWatcher จำเวลา pause ของ GC แต่ละรอบล่าสุด (ring) ไว้ให้ server span ถามว่ามี GC ทับช่วง request ไหม
+// รู้ว่า GC จบรอบผ่าน finalizer ของ sentinel แล้วอ่าน delta ของ pause จาก runtime/metrics
+// (ไม่ใช้ ReadMemStats เพราะ stop-the-world)
+type gcWatcher struct {
+	mu     sync.Mutex
+	events [64]gcEvent
+	next   int
+	total  float64 // pause สะสม (วินาที) ตอนอ่านครั้งล่าสุด
+	sample []metrics.Sample
+	closed atomic.Bool
+}
+
+type gcEvent struct {
+	at    time.Time
+	pause time.Duration
+}
+
+// gcSentinel ใหญ่พอไม่ให้ tiny allocator รวมกับ object อื่น (finalizer จะไม่ถูกเรียกตามรอบ)
+type gcSentinel struct{ _ [16]byte }
+
+func newGCWatcher() *gcWatcher {
+	w := &gcWatcher{sample: []metrics.Sample{{Name: gcPauseMetric}}}
+	w.total = w.readPause()
+	w.arm()
+	return w
+}
+
+func (w *gcWatcher) arm() {
+	runtime.SetFinalizer(new(gcSentinel), func(*gcSentinel) {
+		if w.closed.Load() {
+			return
+		}
+		w.observe(time.Now())
+		w.arm()
+	})
+}
+
+func (w *gcWatcher) observe(at time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	total := w.readPause()
+	if delta := total - w.total; delta > 0 {
+		w.events[w.next%len(w.events)] = gcEvent{at: at, pause: time.Duration(delta * float64(time.Second))}
+		w.next++
+	}
+	w.total = total
+}
+
+// readPause ประมาณ pause สะสมจาก histogram (ค่ากลางของแต่ละ bucket) เรียกภายใต้ mu
+func (w *gcWatcher) readPause() float64 {
+	metrics.Read(w.sample)
+	if w.sample[0].Value.Kind() != metrics.KindFloat64Histogram {
+		return 0
+	}
+	h := w.sample[0].Value.Float64Histogram()
+	var sum float64
+	for i, n := range h.Counts {
+		if n == 0 {
+			continue
+		}
+		lo, hi := h.Buckets[i], h.Buckets[i+1]
+		mid := (lo + hi) / 2
+		switch {
+		case math.IsInf(lo, -1):
+			mid = hi
+		case math.IsInf(hi, 1):
+			mid = lo
+		}
+		sum += float64(n) * mid
+	}
+	return sum
+}
+
+// pauseSince รวม pause ของ GC ที่จบหลัง start
+func (w *gcWatcher) pauseSince(start time.Time) time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var total time.Duration
+	for _, e := range w.events {
+		if !e.at.IsZero() && !e.at.Before(start) {
+			total += e.pause
+		}
+	}
+	return total
+}
+
+// Close หยุดรับ event (sentinel ตัวสุดท้ายจะถูกเก็บในรอบ GC ถัดไป)
+func (w *gcWatcher) Close() error {
+	w.closed.Store(true)
+	return nil
+}
+
+// recordGCPause (Config.GCPauseOnServerSpans) ใส่ gc.pause_during_request_ms ให้ server span
+// ที่มี GC เกิดระหว่าง request และ histogram gc_pause_during_request_ms ต้องเรียกก่อน span.End()
+func (p *Provider) recordGCPause(ctx context.Context, span trace.Span) {
+	if p.gcWatch == nil {
+		return
+	}
+	s, ok := span.(interface {
+		SpanKind() trace.SpanKind
+		StartTime() time.Time
+	})
+	if !ok || s.SpanKind() != trace.SpanKindServer {
+		return
+	}
+	pause := p.gcWatch.pauseSince(s.StartTime())
+	if pause <= 0 {
+		return
+	}
+	span.SetAttributes(attribute.Float64("gc.pause_during_request_ms", durationMs(pause)))
+	p.MetricHistogram("gc_pause_during_request_ms").
+		Unit("ms").
+		Description("GC stop-the-world pause overlapping server requests").
+		Record(ctx, durationMs(pause))
+}
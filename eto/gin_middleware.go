@@ -0,0 +1,266 @@
+package eto
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GinOption ใช้ปรับพฤติกรรมของ GinMiddleware
+type GinOption func(*ginConfig)
+
+type ginConfig struct {
+	routeSLOs  map[string]time.Duration
+	noTracing  map[string]struct{}
+	noMetrics  map[string]struct{}
+	respFmt    HeaderFormat
+	queueTime  bool
+	validation bool
+}
+
+// ginQueueKey key ใน gin.Context ที่ GinMiddleware ฝากเวลาเริ่มไว้ให้ GinHandlerStart
+const ginQueueKey = "eto.queue_start"
+
+type ginQueueMark struct {
+	start       time.Time
+	route       string
+	skipMetrics bool
+}
+
+func routeSet(dst map[string]struct{}, routes []string) map[string]struct{} {
+	if dst == nil {
+		dst = make(map[string]struct{}, len(routes))
+	}
+	for _, r := range routes {
+		dst[r] = struct{}{}
+	}
+	return dst
+}
+
+// WithTracingDisabled ไม่สร้าง span ให้ route เหล่านี้ (ยังนับ metrics ตามปกติ)
+func WithTracingDisabled(routes ...string) GinOption {
+	return func(c *ginConfig) {
+		c.noTracing = routeSet(c.noTracing, routes)
+	}
+}
+
+// WithMetricsDisabled ไม่นับ metrics ให้ route เหล่านี้ (ยังสร้าง span ตามปกติ)
+func WithMetricsDisabled(routes ...string) GinOption {
+	return func(c *ginConfig) {
+		c.noMetrics = routeSet(c.noMetrics, routes)
+	}
+}
+
+// WithSkipRoutes ข้ามทั้ง tracing และ metrics (เช่น /healthz, /metrics) → ไป c.Next() ทันทีไม่มี overhead
+func WithSkipRoutes(routes ...string) GinOption {
+	return func(c *ginConfig) {
+		c.noTracing = routeSet(c.noTracing, routes)
+		c.noMetrics = routeSet(c.noMetrics, routes)
+	}
+}
+
+// WithResponseHeaders เลือก header ที่ใส่กลับใน response: HeaderFormatLegacy (ค่าเริ่มต้น x-trace-id),
+// HeaderFormatW3C (traceparent) หรือ HeaderFormatBoth
+func WithResponseHeaders(format HeaderFormat) GinOption {
+	return func(c *ginConfig) {
+		c.respFmt = format
+	}
+}
+
+// WithQueueTime จับเวลาที่ request ใช้ใน middleware / router ก่อนถึง handler
+// ต้องใส่ eto.GinHandlerStart() เป็น middleware ตัวสุดท้าย (ติดกับ handler) ด้วย
+//
+//	r.Use(eto.GinMiddleware(eto.WithQueueTime()), auth, rateLimit, eto.GinHandlerStart())
+func WithQueueTime() GinOption {
+	return func(c *ginConfig) {
+		c.queueTime = true
+	}
+}
+
+// WithValidationErrors บันทึก binding error จาก c.Bind* / c.Error (gin.ErrorTypeBind) ที่มาจาก
+// go-playground/validator เป็น span event + validation_failures_total หลัง handler จบ
+// (ถ้าใช้ c.ShouldBind* ให้เรียก eto.RecordValidationError เองใน handler)
+func WithValidationErrors() GinOption {
+	return func(c *ginConfig) {
+		c.validation = true
+	}
+}
+
+// WithRouteSLOs กำหนด latency target ต่อ route (key คือ c.FullPath() เช่น "/users/:id")
+// ถ้า request ใช้เวลานานกว่า target จะใส่ slo.exceeded=true ที่ span และนับ http_slo_exceeded_total
+func WithRouteSLOs(slos map[string]time.Duration) GinOption {
+	return func(c *ginConfig) {
+		if c.routeSLOs == nil {
+			c.routeSLOs = make(map[string]time.Duration, len(slos))
+		}
+		for route, target := range slos {
+			if target > 0 {
+				c.routeSLOs[route] = target
+			}
+		}
+	}
+}
+
+// GinMiddleware: extract trace จาก header + สร้าง server span + นับ metrics ของ request
+// ใช้แบบ: r.Use(eto.GinMiddleware(eto.WithRouteSLOs(map[string]time.Duration{"/hello": 200 * time.Millisecond})))
+func GinMiddleware(opts ...GinOption) gin.HandlerFunc {
+	cfg := ginConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	registerInstrumentation("gin", "github.com/gin-gonic/gin", map[string]any{
+		"route_slos":       len(cfg.routeSLOs),
+		"tracing_disabled": len(cfg.noTracing),
+		"metrics_disabled": len(cfg.noMetrics),
+		"queue_time":       cfg.queueTime,
+		"validation":       cfg.validation,
+	})
+
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			// ไม่ match route ไหนเลย → อย่าใช้ URL path ตรง ๆ กัน cardinality ระเบิด
+			route = "unmatched"
+		}
+
+		_, skipTrace := cfg.noTracing[route]
+		_, skipMetrics := cfg.noMetrics[route]
+		skipMetrics = skipMetrics || !std().cfg.EnableMetrics
+
+		// fast path: ปิดทั้งคู่ → ไม่ต้อง extract / จับเวลา / จอง attribute ใด ๆ
+		if skipTrace && skipMetrics {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		if cfg.queueTime {
+			c.Set(ginQueueKey, ginQueueMark{start: start, route: route, skipMetrics: skipMetrics})
+		}
+
+		ctx := Propagate().FromHTTPRequest(c.Request)
+		if skipTrace {
+			c.Request = c.Request.WithContext(ctx)
+			c.Next()
+			elapsed := time.Since(start)
+			if !skipMetrics {
+				cfg.recordMetrics(ctx, route, c, elapsed)
+			}
+			cfg.checkSLO(ctx, trace.SpanFromContext(ctx), route, c.Request.Method, elapsed, skipMetrics)
+			return
+		}
+
+		methodKey, statusKey := "http.method", "http.status_code"
+		if std().Experiment(ExperimentNewSemconv) {
+			methodKey, statusKey = "http.request.method", "http.response.status_code"
+		}
+
+		ctx, span := Trace().
+			Name(route).
+			FromContext(ctx).
+			Kind(trace.SpanKindServer).
+			Attr(methodKey, c.Request.Method).
+			Attr("http.route", route).
+			Start()
+		defer func() { span.End(std().spanEndOptions()...) }()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		// ต้อง set header ก่อน handler เขียน body
+		Propagate().
+			FromContext(ctx).
+			HeaderFormats(cfg.respFmt).
+			ToHTTPResponse(c.Writer)
+
+		c.Next()
+
+		if cfg.validation {
+			for _, e := range c.Errors.ByType(gin.ErrorTypeBind) {
+				RecordValidationError(ctx, e.Err)
+			}
+		}
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int(statusKey, status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, strconv.Itoa(status))
+		}
+
+		elapsed := time.Since(start)
+		if !skipMetrics {
+			cfg.recordMetrics(ctx, route, c, elapsed)
+		}
+		cfg.checkSLO(ctx, span, route, c.Request.Method, elapsed, skipMetrics)
+		std().recordGCPause(ctx, span)
+	}
+}
+
+// GinHandlerStart: middleware ตัวสุดท้ายก่อน handler ใช้คู่กับ WithQueueTime
+// บันทึกเวลาตั้งแต่ GinMiddleware รับ request จนถึงตรงนี้เป็น span attribute http.server.queue_time_ms
+// และ histogram http_server_queue_time_ms{route, method}
+func GinHandlerStart() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		v, ok := c.Get(ginQueueKey)
+		if !ok {
+			c.Next()
+			return
+		}
+		mark := v.(ginQueueMark)
+		queued := time.Since(mark.start)
+		ctx := c.Request.Context()
+
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Float64("http.server.queue_time_ms", durationMs(queued)))
+		if !mark.skipMetrics {
+			MetricHistogram("http_server_queue_time_ms").
+				Attr("route", mark.route).
+				Attr("method", c.Request.Method).
+				Record(ctx, durationMs(queued))
+		}
+		c.Next()
+	}
+}
+
+func (cfg *ginConfig) recordMetrics(ctx context.Context, route string, c *gin.Context, elapsed time.Duration) {
+	statusCode := strconv.Itoa(c.Writer.Status())
+
+	MetricCounter("http_requests_total").
+		Attr("route", route).
+		Attr("method", c.Request.Method).
+		Attr("status_code", statusCode).
+		Add(ctx, 1)
+
+	MetricHistogram("http_request_duration_ms").
+		Attr("route", route).
+		Attr("method", c.Request.Method).
+		Attr("status_code", statusCode).
+		Record(ctx, float64(elapsed.Milliseconds()))
+}
+
+// checkSLO: skipMetrics = route อยู่ใน WithMetricsDisabled / ปิด EnableMetrics → ใส่แค่ span attribute ไม่นับ counter
+func (cfg *ginConfig) checkSLO(ctx context.Context, span trace.Span, route, method string, elapsed time.Duration, skipMetrics bool) {
+	target, ok := cfg.routeSLOs[route]
+	if !ok || elapsed <= target {
+		return
+	}
+
+	span.SetAttributes(
+		attribute.Bool("slo.exceeded", true),
+		attribute.Int64("slo.target_ms", target.Milliseconds()),
+	)
+	if skipMetrics {
+		return
+	}
+
+	MetricCounter("http_slo_exceeded_total").
+		Attr("route", route).
+		Attr("method", method).
+		Add(ctx, 1)
+}
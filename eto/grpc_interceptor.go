@@ -0,0 +1,160 @@
+package eto
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCUnaryServerInterceptor: extract trace จาก metadata + สร้าง server span (rpc.*) + นับ metrics ของ call
+// แบบเดียวกับ GinMiddleware: grpc_server_requests_total / grpc_server_duration_ms {service, method, code}
+// ใช้แบบ: grpc.NewServer(grpc.ChainUnaryInterceptor(eto.GRPCUnaryServerInterceptor()))
+func GRPCUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	registerInstrumentation("grpc", "google.golang.org/grpc", map[string]any{"interceptor": "unary"})
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		call := startGRPCServer(ctx, info.FullMethod, false)
+		resp, err := handler(call.ctx, req)
+		call.end(err)
+		return resp, err
+	}
+}
+
+// GRPCStreamServerInterceptor เหมือน GRPCUnaryServerInterceptor สำหรับ stream
+// (span ได้ rpc.messages_sent / rpc.messages_received เพิ่ม)
+// ใช้แบบ: grpc.NewServer(grpc.ChainStreamInterceptor(eto.GRPCStreamServerInterceptor()))
+func GRPCStreamServerInterceptor() grpc.StreamServerInterceptor {
+	registerInstrumentation("grpc", "google.golang.org/grpc", map[string]any{"interceptor": "stream"})
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		call := startGRPCServer(ss.Context(), info.FullMethod, true)
+		ws := &grpcServerStream{ServerStream: ss, ctx: call.ctx}
+		err := handler(srv, ws)
+		call.span.SetAttributes(
+			attribute.Int64("rpc.messages_sent", ws.sent.Load()),
+			attribute.Int64("rpc.messages_received", ws.received.Load()),
+		)
+		call.end(err)
+		return err
+	}
+}
+
+type grpcServerCall struct {
+	pv      *Provider
+	ctx     context.Context
+	span    trace.Span
+	service string
+	method  string
+	start   time.Time
+}
+
+func startGRPCServer(ctx context.Context, fullMethod string, stream bool) *grpcServerCall {
+	p := std()
+	start := time.Now()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = p.Propagate().FromGRPCMetadata(ctx, md)
+	}
+	service, method := splitGRPCMethod(fullMethod)
+
+	b := p.Trace().
+		Name(strings.TrimPrefix(fullMethod, "/")).
+		FromContext(ctx).
+		Kind(trace.SpanKindServer).
+		Attr("rpc.system", "grpc").
+		Attr("rpc.service", service).
+		Attr("rpc.method", method)
+	if stream {
+		b = b.Attr("rpc.grpc.stream", true)
+	}
+	if pr, ok := peer.FromContext(ctx); ok && pr.Addr != nil {
+		b = b.Attr("network.peer.address", pr.Addr.String())
+	}
+	ctx, span := b.Start()
+	return &grpcServerCall{pv: p, ctx: ctx, span: span, service: service, method: method, start: start}
+}
+
+func (c *grpcServerCall) end(err error) {
+	code := status.Code(err)
+	c.span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+	if err != nil {
+		c.pv.recordError(c.span, err)
+	}
+	if grpcServerError(code) {
+		c.span.SetStatus(otelcodes.Error, c.pv.statusDescription(err))
+	}
+	c.pv.recordGCPause(c.ctx, c.span)
+	c.span.End(c.pv.spanEndOptions()...)
+
+	if !c.pv.cfg.EnableMetrics {
+		return
+	}
+	elapsed := time.Since(c.start)
+	c.pv.MetricCounter("grpc_server_requests_total").
+		Attr("service", c.service).
+		Attr("method", c.method).
+		Attr("code", code.String()).
+		Add(c.ctx, 1)
+	c.pv.MetricHistogram("grpc_server_duration_ms").
+		Attr("service", c.service).
+		Attr("method", c.method).
+		Attr("code", code.String()).
+		Record(c.ctx, durationMs(elapsed))
+}
+
+// grpcServerError code ที่ถือว่าฝั่ง server ผิด (ตาม semconv ของ gRPC server span)
+// code อย่าง NotFound / InvalidArgument เป็นความผิดของ client จึงไม่ทำให้ span เป็น error
+func grpcServerError(code grpccodes.Code) bool {
+	switch code {
+	case grpccodes.Unknown, grpccodes.DeadlineExceeded, grpccodes.Unimplemented,
+		grpccodes.Internal, grpccodes.Unavailable, grpccodes.DataLoss:
+		return true
+	}
+	return false
+}
+
+// splitGRPCMethod แยก "/pkg.Service/Method" เป็น service กับ method
+func splitGRPCMethod(fullMethod string) (string, string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "unknown", name
+}
+
+// grpcServerStream ส่ง ctx ที่มี span ให้ handler และนับจำนวน message
+type grpcServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	sent     atomic.Int64
+	received atomic.Int64
+}
+
+func (s *grpcServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *grpcServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Add(1)
+	}
+	return err
+}
+
+func (s *grpcServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Add(1)
+	}
+	return err
+}
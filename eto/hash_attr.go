@@ -0,0 +1,85 @@
+package eto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// HashAttr attribute ที่ค่าเป็น SHA-256 (ผสม Config.HashSalt) ของ value แทนค่าจริง
+// ค่าเดียวกันได้ hash เดียวกันเสมอ จึงยัง correlate ข้าม span / log / metric ได้โดยไม่เปิดเผย PII
+//
+//	eto.Trace().Name("signup").Attrs(eto.HashAttr("user.email", email)).Start()
+func HashAttr(key, value string) attribute.KeyValue {
+	return std().HashAttr(key, value)
+}
+
+// HashAttr ของ Provider ตัวนี้ (ดู eto.HashAttr)
+func (p *Provider) HashAttr(key, value string) attribute.KeyValue {
+	return attribute.String(key, p.hashValue(value))
+}
+
+func (p *Provider) hashValue(v string) string {
+	h := sha256.New()
+	h.Write([]byte(p.cfg.HashSalt))
+	h.Write([]byte(v))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func newHashKeys(cfg Config) map[string]struct{} {
+	if len(cfg.HashAttrKeys) == 0 {
+		return nil
+	}
+	keys := make(map[string]struct{}, len(cfg.HashAttrKeys))
+	for _, k := range cfg.HashAttrKeys {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
+
+// hashAttrs แทนค่าของ key ใน Config.HashAttrKeys ด้วย hash (แก้ใน slice เดิม ใช้กับ slice ที่เป็นของ caller เท่านั้น)
+func (p *Provider) hashAttrs(attrs []attribute.KeyValue) {
+	if len(p.hashKeys) == 0 {
+		return
+	}
+	for i, kv := range attrs {
+		if _, ok := p.hashKeys[string(kv.Key)]; ok {
+			attrs[i] = p.HashAttr(string(kv.Key), kv.Value.Emit())
+		}
+	}
+}
+
+// hashFields เหมือน hashAttrs สำหรับ zap field แต่ copy ก่อนแก้ (field อาจแชร์กับ Logger ที่ preset ไว้)
+func (p *Provider) hashFields(fields []zap.Field) []zap.Field {
+	if len(p.hashKeys) == 0 {
+		return fields
+	}
+	var out []zap.Field
+	for i, f := range fields {
+		if _, ok := p.hashKeys[f.Key]; !ok {
+			continue
+		}
+		if out == nil {
+			out = append([]zap.Field(nil), fields...)
+		}
+		out[i] = zap.String(f.Key, p.hashValue(zapFieldString(f)))
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// zapFieldString ค่าของ field เป็น string เพื่อนำไป hash
+func zapFieldString(f zap.Field) string {
+	if f.Type == zapcore.StringType {
+		return f.String
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return fmt.Sprint(enc.Fields[f.Key])
+}
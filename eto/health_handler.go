@@ -0,0 +1,44 @@
+package eto
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type healthResponse struct {
+	Status           string               `json:"status"`
+	Error            string               `json:"error,omitempty"`
+	Service          string               `json:"service,omitempty"`
+	Environment      string               `json:"environment,omitempty"`
+	Instrumentations []Instrumentation    `json:"instrumentations"`
+	Config           ConfigSnapshot       `json:"config"`
+	Pipeline         PipelineHealthReport `json:"pipeline"`
+}
+
+// HealthHandler: debug / health endpoint แสดงผลของ Health(), Instrumentations(), CurrentConfig() และ PipelineHealth()
+// ตอบ 200 ถ้าปกติ, 503 ถ้า pipeline มีปัญหา
+// ใช้แบบ: mux.Handle("/debug/otel", eto.HealthHandler())
+func HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := std()
+		resp := healthResponse{
+			Status:           "ok",
+			Service:          p.cfg.ServiceName,
+			Environment:      p.cfg.Environment,
+			Instrumentations: Instrumentations(),
+			Config:           p.CurrentConfig(),
+			Pipeline:         p.PipelineHealth(),
+		}
+
+		code := http.StatusOK
+		if err := p.Health(); err != nil {
+			resp.Status = "degraded"
+			resp.Error = err.Error()
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
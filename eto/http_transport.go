@@ -0,0 +1,119 @@
+package eto
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPTransportOption ใช้ปรับพฤติกรรมของ NewHTTPTransport
+type HTTPTransportOption func(*httpTransport)
+
+// WithRequestHeaders เลือก header ที่ inject ลง request ขาออก: HeaderFormatW3C (ค่าเริ่มต้น traceparent),
+// HeaderFormatLegacy (x-trace-id) หรือ HeaderFormatBoth สำหรับ service ปลายทางที่ยังอ่านแต่ legacy
+func WithRequestHeaders(format HeaderFormat) HTTPTransportOption {
+	return func(t *httpTransport) {
+		t.format = format
+	}
+}
+
+// WithSpanNameFormatter ตั้งชื่อ span เอง (ค่าเริ่มต้น "HTTP <method>" แบบเดียวกับ InstrumentResty)
+func WithSpanNameFormatter(fn func(*http.Request) string) HTTPTransportOption {
+	return func(t *httpTransport) {
+		t.spanName = fn
+	}
+}
+
+type httpTransport struct {
+	base     http.RoundTripper
+	format   HeaderFormat
+	spanName func(*http.Request) string
+}
+
+// NewHTTPTransport: ห่อ http.RoundTripper ให้ request ขาออกได้ client span + inject trace context
+// + นับ http_client_requests_total / http_client_request_duration_ms แบบเดียวกับ InstrumentResty
+// span จบเมื่อได้ response header (ไม่รอ body) base เป็น nil = http.DefaultTransport
+// ใช้แบบ: client := &http.Client{Transport: eto.NewHTTPTransport(nil)}
+func NewHTTPTransport(base http.RoundTripper, opts ...HTTPTransportOption) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &httpTransport{base: base}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(t)
+		}
+	}
+	registerInstrumentation("net/http", "net/http", map[string]any{"transport": true})
+	return t
+}
+
+// httpClientKeys ชื่อ attribute ของ span ฝั่ง HTTP client ตาม ExperimentNewSemconv (ใช้ร่วมกับ resty)
+func (p *Provider) httpClientKeys() (methodKey, statusKey, urlKey string) {
+	if p.Experiment(ExperimentNewSemconv) {
+		return "http.request.method", "http.response.status_code", "url.full"
+	}
+	return "http.method", "http.status_code", "http.url"
+}
+
+func (t *httpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	p := std()
+	start := time.Now()
+
+	methodKey, statusKey, urlKey := p.httpClientKeys()
+
+	name := "HTTP " + req.Method
+	if t.spanName != nil {
+		name = t.spanName(req)
+	}
+	b := p.Trace().
+		Name(name).
+		FromContext(req.Context()).
+		Kind(trace.SpanKindClient).
+		Attr(methodKey, req.Method).
+		Attr(urlKey, req.URL.Redacted()).
+		Attr("server.address", req.URL.Hostname())
+	if svc := p.peerService(req.URL.Hostname()); svc != "" {
+		b = b.Attr("peer.service", svc)
+	}
+	ctx, span := b.Start()
+
+	// RoundTripper ห้ามแก้ request ของ caller → clone ก่อน inject header
+	out := req.Clone(ctx)
+	p.Propagate().FromContext(ctx).HeaderFormats(t.format).ToHTTPHeader(out.Header)
+
+	resp, err := t.base.RoundTrip(out)
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+		span.SetAttributes(attribute.Int(statusKey, status))
+	}
+	outcome := "success"
+	switch {
+	case err != nil:
+		outcome = "error"
+		p.recordError(span, err)
+		span.SetStatus(codes.Error, p.statusDescription(err))
+	case status >= 500:
+		outcome = "error"
+		span.SetStatus(codes.Error, strconv.Itoa(status))
+	}
+	span.End(p.spanEndOptions()...)
+
+	p.MetricCounter("http_client_requests_total").
+		Attr("method", req.Method).
+		Attr("status_code", strconv.Itoa(status)).
+		Attr("status", outcome).
+		Add(ctx, 1)
+	p.MetricHistogram("http_client_request_duration_ms").
+		Attr("method", req.Method).
+		Attr("status", outcome).
+		Record(ctx, float64(time.Since(start).Milliseconds()))
+
+	return resp, err
+}
@@ -0,0 +1,46 @@
+package eto
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SequentialIDGenerator สร้าง trace / span id เรียงลำดับ 1, 2, 3, ... (ไม่สุ่ม) สำหรับ test
+// ที่ assert span ที่ export ออกมากับ golden file ใช้แบบ eto.Config{IDGenerator: eto.SequentialIDGenerator()}
+// ห้ามใช้ใน production: id ซ้ำกันข้าม process
+func SequentialIDGenerator() sdktrace.IDGenerator {
+	return &sequentialIDGenerator{}
+}
+
+type sequentialIDGenerator struct {
+	mu     sync.Mutex
+	traceN uint64
+	spanN  uint64
+}
+
+func (g *sequentialIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.traceN++
+	g.spanN++
+	var tid trace.TraceID
+	binary.BigEndian.PutUint64(tid[8:], g.traceN)
+	return tid, g.spanIDLocked()
+}
+
+func (g *sequentialIDGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.spanN++
+	return g.spanIDLocked()
+}
+
+func (g *sequentialIDGenerator) spanIDLocked() trace.SpanID {
+	var sid trace.SpanID
+	binary.BigEndian.PutUint64(sid[:], g.spanN)
+	return sid
+}
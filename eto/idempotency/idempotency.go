@@ -0,0 +1,91 @@
+// Package idempotency วัดว่ากลไกกันซ้ำของ consumer / API ทำงานบ่อยแค่ไหน
+// (inbox เจอ message ซ้ำ, idempotency key ถูกใช้ซ้ำ, message ถูก replay) เป็นทั้ง metric และ attribute บน span ใน ctx
+//
+// ใช้แบบ:
+//
+//	seen, err := inbox.Seen(ctx, msg.ID)
+//	if idempotency.Inbox(ctx, "order-consumer", msg.ID, seen) {
+//	    return nil // ซ้ำ ข้ามได้
+//	}
+//	idempotency.Replay(ctx, "order-consumer", delivery.Attempt)
+package idempotency
+
+import (
+	"context"
+
+	"github.com/Maximumsoft-Co-LTD/otelgo/eto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ชื่อ metric ที่ package นี้ส่ง
+const (
+	MetricInboxChecks = "messaging_inbox_checks_total" // {consumer, result=hit|miss}
+	MetricKeyReuse    = "idempotency_key_reuse_total"  // {operation}
+	MetricReplays     = "messaging_replays_total"      // {consumer}
+	MetricAttempts    = "messaging_delivery_attempts"  // histogram {consumer}
+)
+
+// Inbox บันทึกผลการเช็ค inbox ของ message หนึ่งตัว (hit = เคยประมวลผลแล้ว) แล้วคืน duplicate กลับ
+// นับทั้ง hit และ miss เพื่อให้คิดอัตราซ้ำได้
+func Inbox(ctx context.Context, consumer, messageID string, duplicate bool) bool {
+	result := "miss"
+	if duplicate {
+		result = "hit"
+	}
+	eto.MetricCounter(MetricInboxChecks).
+		Description("inbox lookups of consumed messages by result").
+		Attr("consumer", consumer).
+		Attr("result", result).
+		Add(ctx, 1)
+
+	span := trace.SpanFromContext(ctx)
+	attrs := []attribute.KeyValue{attribute.Bool("messaging.inbox.duplicate", duplicate)}
+	if messageID != "" {
+		attrs = append(attrs, attribute.String("messaging.message.id", messageID))
+	}
+	span.SetAttributes(attrs...)
+	if duplicate {
+		span.AddEvent("messaging.inbox.duplicate", trace.WithAttributes(attribute.String("messaging.consumer", consumer)))
+	}
+	return duplicate
+}
+
+// KeyReuse บันทึกว่า idempotency key ของ operation ถูกส่งมาซ้ำ (ตอบผลเดิมแทนการทำใหม่)
+// key ไม่ถูกใส่ใน metric (cardinality) แต่อยู่บน span ไว้ตามหา request ต้นทาง
+func KeyReuse(ctx context.Context, operation, key string) {
+	eto.MetricCounter(MetricKeyReuse).
+		Description("requests answered from a stored idempotency key").
+		Attr("operation", operation).
+		Add(ctx, 1)
+
+	span := trace.SpanFromContext(ctx)
+	attrs := []attribute.KeyValue{attribute.Bool("idempotency.key_reused", true)}
+	if key != "" {
+		attrs = append(attrs, attribute.String("idempotency.key", key))
+	}
+	span.SetAttributes(attrs...)
+}
+
+// Replay บันทึกรอบการส่งของ message (attempt เริ่มที่ 1) รอบที่ 2 ขึ้นไปนับเป็น replay
+// ใช้กับ redelivery ของ broker หรือการ replay จาก DLQ
+func Replay(ctx context.Context, consumer string, attempt int) {
+	if attempt < 1 {
+		return
+	}
+	eto.MetricHistogram(MetricAttempts).
+		Description("delivery attempt number of consumed messages").
+		Attr("consumer", consumer).
+		Record(ctx, float64(attempt))
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("messaging.delivery.attempt", attempt))
+	if attempt == 1 {
+		return
+	}
+	eto.MetricCounter(MetricReplays).
+		Description("messages consumed again after a previous attempt").
+		Attr("consumer", consumer).
+		Add(ctx, 1)
+	span.SetAttributes(attribute.Bool("messaging.replayed", true))
+}
@@ -0,0 +1,96 @@
+package eto
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// defaultInstrumentCacheSize ขนาด cache ต่อชนิด instrument เมื่อไม่ได้ตั้ง Config.InstrumentCacheSize
+const defaultInstrumentCacheSize = 1000
+
+// instrumentCache LRU ของ instrument ตามชื่อ: จำกัดขนาด map ของ eto เอง และเตือนเมื่อชื่อ metric มาจากข้อมูล (dynamic name)
+// ตัวที่ถูกไล่ออกไม่ได้ถูกปล่อยจาก meter ของ SDK (เก็บทุกชื่อไว้ตลอด) memory รวมจึงยังโตตามจำนวนชื่อ
+type instrumentCache[T any] struct {
+	mu    sync.Mutex
+	limit int
+	ll    *list.List // front = ใช้ล่าสุด
+	items map[string]*list.Element
+}
+
+type instrumentEntry[T any] struct {
+	name string
+	inst T
+}
+
+func newInstrumentCache[T any](limit int) *instrumentCache[T] {
+	if limit <= 0 {
+		limit = defaultInstrumentCacheSize
+	}
+	return &instrumentCache[T]{limit: limit, ll: list.New(), items: map[string]*list.Element{}}
+}
+
+// getOrCreate คืน instrument จาก cache หรือเรียก create (ถือ lock ไว้ กันสร้างซ้ำ)
+// ok = false เมื่อ create ล้มเหลว, evicted = ชื่อที่ถูกไล่ออกเพื่อให้ที่ (ว่าง = ไม่มี)
+func (c *instrumentCache[T]) getOrCreate(name string, hit func(), create func() (T, bool)) (inst T, evicted string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, found := c.items[name]; found {
+		c.ll.MoveToFront(el)
+		if hit != nil {
+			hit()
+		}
+		return el.Value.(*instrumentEntry[T]).inst, "", true
+	}
+
+	inst, ok = create()
+	if !ok {
+		return inst, "", false
+	}
+	c.items[name] = c.ll.PushFront(&instrumentEntry[T]{name: name, inst: inst})
+	if c.ll.Len() > c.limit {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		evicted = oldest.Value.(*instrumentEntry[T]).name
+		delete(c.items, evicted)
+	}
+	return inst, evicted, true
+}
+
+// instrumentEvicted นับ eto_instrument_evictions_total{kind} และ warn ครั้งแรกของแต่ละ kind
+// (ถูกไล่ออกแปลว่าจำนวนชื่อ metric เกิน cache = น่าจะเอา id / path มาเป็นชื่อ metric)
+func (p *Provider) instrumentEvicted(kind, name string) {
+	p.evictionOnce.Do(func() {
+		c, err := p.meter.Int64Counter("eto_instrument_evictions_total",
+			metric.WithUnit("1"),
+			metric.WithDescription("instruments evicted from eto's instrument cache"),
+		)
+		if err == nil {
+			p.evictions = c
+		}
+	})
+	if p.evictions != nil {
+		p.evictions.Add(context.Background(), 1, metric.WithAttributes(attribute.String("kind", kind)))
+	}
+
+	if _, warned := p.metricWarned.LoadOrStore("evict\x00"+kind, struct{}{}); warned || p.logger == nil {
+		return
+	}
+	p.logger.Warn("eto: instrument cache full, evicting least recently used instruments (too many distinct metric names?)",
+		zap.String("kind", kind),
+		zap.String("evicted", name),
+		zap.Int("cache_size", p.instrumentCacheSize()),
+	)
+}
+
+func (p *Provider) instrumentCacheSize() int {
+	if p.cfg.InstrumentCacheSize > 0 {
+		return p.cfg.InstrumentCacheSize
+	}
+	return defaultInstrumentCacheSize
+}
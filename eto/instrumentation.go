@@ -0,0 +1,69 @@
+package eto
+
+import (
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// Instrumentation ข้อมูลของ integration ที่ถูกเปิดใช้ใน process นี้ (gin, resty, amqp, sql, ...)
+type Instrumentation struct {
+	Name    string         `json:"name"`
+	Library string         `json:"library,omitempty"`
+	Version string         `json:"version,omitempty"`
+	Config  map[string]any `json:"config,omitempty"`
+}
+
+var (
+	instrumentationsMu sync.Mutex
+	instrumentations   = map[string]Instrumentation{}
+)
+
+// registerInstrumentation เรียกจาก integration ตอนถูกสร้าง / ใช้ครั้งแรก
+// library คือ module path ของ lib ที่ครอบ (ใช้หา version จาก build info) ว่างได้ถ้าเป็น stdlib
+func registerInstrumentation(name, library string, cfg map[string]any) {
+	instrumentationsMu.Lock()
+	defer instrumentationsMu.Unlock()
+
+	instrumentations[name] = Instrumentation{
+		Name:    name,
+		Library: library,
+		Version: moduleVersion(library),
+		Config:  cfg,
+	}
+}
+
+// Instrumentations คืนรายการ integration ที่ active อยู่ (เรียงตามชื่อ) ไว้ audit coverage
+func Instrumentations() []Instrumentation {
+	instrumentationsMu.Lock()
+	defer instrumentationsMu.Unlock()
+
+	out := make([]Instrumentation, 0, len(instrumentations))
+	for _, in := range instrumentations {
+		out = append(out, in)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func moduleVersion(path string) string {
+	if path == "" {
+		return ""
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Path == path {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == path {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package eto
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ioStats นับ bytes + เวลา แล้วสรุปเป็น span attributes / histograms ครั้งเดียวตอนจบ
+type ioStats struct {
+	ctx   context.Context
+	name  string
+	op    string
+	bytes int64
+	start time.Time
+	once  sync.Once
+}
+
+func newIOStats(ctx context.Context, name, op string) ioStats {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if name == "" {
+		name = "unnamed"
+	}
+	return ioStats{
+		ctx:   ctx,
+		name:  name,
+		op:    op,
+		start: time.Now(),
+	}
+}
+
+func (s *ioStats) finish() {
+	s.once.Do(func() {
+		elapsed := time.Since(s.start)
+
+		var throughput float64
+		if secs := elapsed.Seconds(); secs > 0 {
+			throughput = float64(s.bytes) / secs
+		}
+
+		span := trace.SpanFromContext(s.ctx)
+		span.SetAttributes(
+			attribute.Int64("io."+s.op+".bytes", s.bytes),
+			attribute.Int64("io."+s.op+".duration_ms", elapsed.Milliseconds()),
+			attribute.Float64("io."+s.op+".throughput_bytes_per_sec", throughput),
+		)
+
+		MetricHistogram("io_transfer_bytes").
+			Unit("By").
+			Attr("name", s.name).
+			Attr("operation", s.op).
+			Record(s.ctx, float64(s.bytes))
+
+		MetricHistogram("io_throughput_bytes_per_sec").
+			Unit("By/s").
+			Attr("name", s.name).
+			Attr("operation", s.op).
+			Record(s.ctx, throughput)
+	})
+}
+
+// InstrumentedReader ห่อ io.Reader เพื่อนับ bytes/throughput
+type InstrumentedReader struct {
+	r     io.Reader
+	stats ioStats
+}
+
+// InstrumentReader: ห่อ reader (เช่น upload body) แล้วบันทึก bytes + throughput ลง span ใน ctx
+// และ histogram io_transfer_bytes / io_throughput_bytes_per_sec ตอนอ่านถึง EOF หรือ Close()
+// ใช้แบบ: body := eto.InstrumentReader(ctx, "media.upload", r.Body); defer body.Close()
+func InstrumentReader(ctx context.Context, name string, r io.Reader) *InstrumentedReader {
+	return &InstrumentedReader{
+		r:     r,
+		stats: newIOStats(ctx, name, "read"),
+	}
+}
+
+func (ir *InstrumentedReader) Read(p []byte) (int, error) {
+	n, err := ir.r.Read(p)
+	ir.stats.bytes += int64(n)
+	if errors.Is(err, io.EOF) {
+		ir.stats.finish()
+	}
+	return n, err
+}
+
+// Close สรุป metrics (ถ้ายังไม่สรุป) และ close reader ตัวจริงถ้าเป็น io.Closer
+func (ir *InstrumentedReader) Close() error {
+	ir.stats.finish()
+	if c, ok := ir.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// BytesRead จำนวน bytes ที่อ่านไปแล้ว
+func (ir *InstrumentedReader) BytesRead() int64 {
+	return ir.stats.bytes
+}
+
+// InstrumentedWriter ห่อ io.Writer เพื่อนับ bytes/throughput
+type InstrumentedWriter struct {
+	w     io.Writer
+	stats ioStats
+}
+
+// InstrumentWriter: ห่อ writer (เช่น download response / ไฟล์ปลายทาง) ต้องเรียก Close() เพื่อสรุป metrics
+// ใช้แบบ: w := eto.InstrumentWriter(ctx, "media.download", f); defer w.Close()
+func InstrumentWriter(ctx context.Context, name string, w io.Writer) *InstrumentedWriter {
+	return &InstrumentedWriter{
+		w:     w,
+		stats: newIOStats(ctx, name, "write"),
+	}
+}
+
+func (iw *InstrumentedWriter) Write(p []byte) (int, error) {
+	n, err := iw.w.Write(p)
+	iw.stats.bytes += int64(n)
+	return n, err
+}
+
+// Close สรุป metrics และ close writer ตัวจริงถ้าเป็น io.Closer
+func (iw *InstrumentedWriter) Close() error {
+	iw.stats.finish()
+	if c, ok := iw.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// BytesWritten จำนวน bytes ที่เขียนไปแล้ว
+func (iw *InstrumentedWriter) BytesWritten() int64 {
+	return iw.stats.bytes
+}
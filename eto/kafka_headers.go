@@ -0,0 +1,158 @@
+package eto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ---------- Kafka (sarama / kafka-go / confluent-kafka-go) ----------
+
+// kafkaHeaderCarrier ทำให้ slice ของ header ของ Kafka client ใช้กับ propagator ได้โดยไม่ต้อง import client
+// (ไม่ให้ sarama / kafka-go ติดไปกับ dependency ของ eto) element ต้องเป็น struct หรือ pointer ของ struct
+// ที่มี field Key (string หรือ []byte) และ Value []byte ซึ่งตรงกับ sarama.RecordHeader, kafka-go kafka.Header
+// และ confluent-kafka-go kafka.Header
+// key ซ้ำใน slice: Get คืนตัวแรก, Set แทนที่ตัวแรก (ไม่ append ซ้ำตอน re-publish)
+type kafkaHeaderCarrier struct {
+	headers reflect.Value // slice ที่ addressable (Set append ได้)
+	elem    reflect.Type  // struct ของ header
+	ptr     bool          // element เป็น *struct
+}
+
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// newKafkaHeaderCarrier: headers ต้องเป็น slice ที่ addressable
+func newKafkaHeaderCarrier(headers reflect.Value) (kafkaHeaderCarrier, bool) {
+	if headers.Kind() != reflect.Slice {
+		return kafkaHeaderCarrier{}, false
+	}
+	elem, ptr := headers.Type().Elem(), false
+	if elem.Kind() == reflect.Pointer {
+		elem, ptr = elem.Elem(), true
+	}
+	if elem.Kind() != reflect.Struct {
+		return kafkaHeaderCarrier{}, false
+	}
+	key, ok := elem.FieldByName("Key")
+	if !ok || (key.Type.Kind() != reflect.String && key.Type != bytesType) {
+		return kafkaHeaderCarrier{}, false
+	}
+	val, ok := elem.FieldByName("Value")
+	if !ok || val.Type != bytesType {
+		return kafkaHeaderCarrier{}, false
+	}
+	return kafkaHeaderCarrier{headers: headers, elem: elem, ptr: ptr}, true
+}
+
+// header คืน struct ของ element ที่ i (invalid ถ้าเป็น nil pointer)
+func (c kafkaHeaderCarrier) header(i int) reflect.Value {
+	h := c.headers.Index(i)
+	if c.ptr {
+		if h.IsNil() {
+			return reflect.Value{}
+		}
+		h = h.Elem()
+	}
+	return h
+}
+
+func (c kafkaHeaderCarrier) keyOf(h reflect.Value) string {
+	if !h.IsValid() {
+		return ""
+	}
+	k := h.FieldByName("Key")
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+	return string(k.Bytes())
+}
+
+// newHeader สร้าง element ใหม่ (struct หรือ *struct ตาม slice)
+func (c kafkaHeaderCarrier) newHeader(key, val string) reflect.Value {
+	h := reflect.New(c.elem)
+	k := h.Elem().FieldByName("Key")
+	if k.Kind() == reflect.String {
+		k.SetString(key)
+	} else {
+		k.SetBytes([]byte(key))
+	}
+	h.Elem().FieldByName("Value").SetBytes([]byte(val))
+	if c.ptr {
+		return h
+	}
+	return h.Elem()
+}
+
+func (c kafkaHeaderCarrier) Get(key string) string {
+	for i := 0; i < c.headers.Len(); i++ {
+		if h := c.header(i); c.keyOf(h) == key {
+			return string(h.FieldByName("Value").Bytes())
+		}
+	}
+	return ""
+}
+
+func (c kafkaHeaderCarrier) Set(key, val string) {
+	for i := 0; i < c.headers.Len(); i++ {
+		if c.keyOf(c.header(i)) == key {
+			c.headers.Index(i).Set(c.newHeader(key, val))
+			return
+		}
+	}
+	c.headers.Set(reflect.Append(c.headers, c.newHeader(key, val)))
+}
+
+func (c kafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, c.headers.Len())
+	for i := 0; i < c.headers.Len(); i++ {
+		keys = append(keys, c.keyOf(c.header(i)))
+	}
+	return keys
+}
+
+// FromKafkaHeaders: ดึง trace context จาก headers ของ Kafka message
+// รับ []*sarama.RecordHeader (ConsumerMessage), []sarama.RecordHeader หรือ []kafka.Header (kafka-go / confluent)
+// ใช้แบบ: ctx := eto.Propagate().FromContext(baseCtx).FromKafkaHeaders(msg.Headers)
+func (p *PropagationBuilder) FromKafkaHeaders(headers any) context.Context {
+	if !p.ready() {
+		return p.ctx
+	}
+	v := reflect.ValueOf(headers)
+	if v.Kind() != reflect.Slice {
+		p.setErr(fmt.Errorf("eto.Propagate().FromKafkaHeaders: unsupported headers type %T", headers))
+		return p.ctx
+	}
+	// copy ลง slice ที่ addressable (Extract อ่านอย่างเดียว ไม่แตะ headers ของ caller)
+	addr := reflect.New(v.Type()).Elem()
+	addr.Set(v)
+	carrier, ok := newKafkaHeaderCarrier(addr)
+	if !ok {
+		p.setErr(fmt.Errorf("eto.Propagate().FromKafkaHeaders: unsupported headers type %T", headers))
+		return p.ctx
+	}
+	ctx := p.pv.propagator.Extract(p.ctx, carrier)
+	p.validateExtract(carrier, ctx)
+	return ctx
+}
+
+// ToKafkaHeaders: inject trace context ลง headers เวลาจะ produce (ต้องส่ง pointer เพราะอาจ append)
+// รับ *[]sarama.RecordHeader (ProducerMessage), *[]*sarama.RecordHeader หรือ *[]kafka.Header (kafka-go / confluent)
+// ใช้แบบ: eto.Propagate().FromContext(ctx).ToKafkaHeaders(&msg.Headers)
+func (p *PropagationBuilder) ToKafkaHeaders(headers any) {
+	v := reflect.ValueOf(headers)
+	if v.Kind() != reflect.Pointer {
+		p.setErr(fmt.Errorf("eto.Propagate().ToKafkaHeaders: unsupported headers type %T", headers))
+		return
+	}
+	if v.IsNil() {
+		p.setErr(errors.New("eto.Propagate().ToKafkaHeaders: headers pointer is nil"))
+		return
+	}
+	carrier, ok := newKafkaHeaderCarrier(v.Elem())
+	if !ok {
+		p.setErr(fmt.Errorf("eto.Propagate().ToKafkaHeaders: unsupported headers type %T", headers))
+		return
+	}
+	p.Inject(p.ctx, carrier)
+}
@@ -0,0 +1,50 @@
+package eto
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	legacyTraceIDHeader = "x-trace-id"
+	legacySpanIDHeader  = "x-span-id"
+)
+
+// legacyPropagator อ่าน x-trace-id / x-span-id จาก caller รุ่นเก่าที่ยังไม่ส่ง traceparent
+// ทำงานเฉพาะตอนไม่มี traceparent เท่านั้น (W3C ชนะเสมอ) ฝั่ง inject ไม่ทำอะไร
+// เพราะการเขียน legacy header ถูกคุมด้วย HeaderFormats อยู่แล้ว
+type legacyPropagator struct{}
+
+var _ propagation.TextMapPropagator = legacyPropagator{}
+
+func (legacyPropagator) Inject(context.Context, propagation.TextMapCarrier) {}
+
+func (legacyPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	if carrier.Get("traceparent") != "" {
+		return ctx
+	}
+
+	traceID, err := trace.TraceIDFromHex(strings.TrimSpace(carrier.Get(legacyTraceIDHeader)))
+	if err != nil {
+		return ctx
+	}
+	spanID, err := trace.SpanIDFromHex(strings.TrimSpace(carrier.Get(legacySpanIDHeader)))
+	if err != nil {
+		return ctx
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+func (legacyPropagator) Fields() []string {
+	return []string{legacyTraceIDHeader, legacySpanIDHeader}
+}
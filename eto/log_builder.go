@@ -2,12 +2,15 @@ package eto
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"path/filepath"
 	"runtime"
 	"strings"
-	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	otellog "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -24,14 +27,25 @@ const (
 )
 
 type LogBuilder struct {
+	pv     *Provider
 	ctx    context.Context
 	level  LogLevel
 	msg    string
 	fields []zap.Field
+	worker bool
+	err    error
+	stack  bool
+	caller string // กำหนดจาก bridge (เช่น slog ที่มี PC ของจุดเรียกมาแล้ว) แทนการเดิน stack
 }
 
 func Log() *LogBuilder {
+	return std().Log()
+}
+
+// Log สร้าง log builder ที่ส่งผ่าน logger ของ Provider ตัวนี้
+func (p *Provider) Log() *LogBuilder {
 	return &LogBuilder{
+		pv:    p,
 		ctx:   context.Background(),
 		level: levelInfo,
 	}
@@ -49,27 +63,74 @@ func (b *LogBuilder) Info() *LogBuilder  { b.level = levelInfo; return b }
 func (b *LogBuilder) Warn() *LogBuilder  { b.level = levelWarn; return b }
 func (b *LogBuilder) Error() *LogBuilder { b.level = levelError; return b }
 
+// Worker ใส่ worker.id (จาก WithWorkerID) และ goroutine.id ลง log ปิดไว้เป็นค่าเริ่มต้นเพราะมี overhead
+func (b *LogBuilder) Worker(enable bool) *LogBuilder {
+	b.worker = enable
+	return b
+}
+
+// Err แนบ error: zap ได้ field error + error.type, OTEL ได้ exception.message / exception.type
+// (err nil = ไม่ทำอะไร) ใช้คู่กับ Stack(true) ถ้าต้องการ stack trace ด้วย
+func (b *LogBuilder) Err(err error) *LogBuilder {
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// Stack แนบ stack trace ของจุดที่เรียก Send (zap: stacktrace, OTEL: exception.stacktrace) ปิดไว้เป็นค่าเริ่มต้นเพราะแพง
+func (b *LogBuilder) Stack(enable bool) *LogBuilder {
+	b.stack = enable
+	return b
+}
+
+// errorType ชื่อชนิดของ error โดยข้าม wrapper ของ fmt.Errorf("...: %w") ไปหาต้นเหตุ
+func errorType(err error) string {
+	for {
+		t := fmt.Sprintf("%T", err)
+		if t != "*fmt.wrapError" {
+			return t
+		}
+		inner := errors.Unwrap(err)
+		if inner == nil {
+			return t
+		}
+		err = inner
+	}
+}
+
 func (b *LogBuilder) Msg(msg string) *LogBuilder {
 	b.msg = msg
 	return b
 }
 
+// Msgf ตั้งข้อความแบบ fmt.Sprintf สำหรับโค้ดที่ย้ายมาจาก log.Printf
+// (ค่าที่อยากค้นหา / กรองใน backend ควรใส่เป็น Field แทน)
+func (b *LogBuilder) Msgf(format string, args ...any) *LogBuilder {
+	b.msg = fmt.Sprintf(format, args...)
+	return b
+}
+
 func (b *LogBuilder) Field(key string, val any) *LogBuilder {
+	b.fields = append(b.fields, anyField(key, val))
+	return b
+}
+
+func anyField(key string, val any) zap.Field {
 	switch v := val.(type) {
 	case string:
-		b.fields = append(b.fields, zap.String(key, v))
+		return zap.String(key, v)
 	case int:
-		b.fields = append(b.fields, zap.Int(key, v))
+		return zap.Int(key, v)
 	case int64:
-		b.fields = append(b.fields, zap.Int64(key, v))
+		return zap.Int64(key, v)
 	case float64:
-		b.fields = append(b.fields, zap.Float64(key, v))
+		return zap.Float64(key, v)
 	case bool:
-		b.fields = append(b.fields, zap.Bool(key, v))
+		return zap.Bool(key, v)
 	default:
-		b.fields = append(b.fields, zap.Any(key, v))
+		return zap.Any(key, v)
 	}
-	return b
 }
 
 func (b *LogBuilder) Fields(fields ...zap.Field) *LogBuilder {
@@ -107,49 +168,82 @@ func (b *LogBuilder) severityText() string {
 	}
 }
 
-func logCaller() string {
+// callerFrame ผลของ pc หนึ่งตัว (cache ไว้ใน Provider.callers เพราะ symbolize ด้วย CallersFrames แพง)
+type callerFrame struct {
+	use    bool // มี frame ที่ผ่าน SkipCallerPkgs / SkipCallerFiles
+	caller string
+}
+
+// logCaller หา "file:line func" ของจุดที่เรียก Send
+//   - ไม่มี SkipCallerPkgs / SkipCallerFiles: อ่าน pc เดียวที่ระยะคงที่ (Config.CallerSkip)
+//   - มี: ไล่ stack ทีละ pc จนเจอ frame ที่ใช้ได้ (สูงสุด 32 ชั้น)
+//
+// ทั้งสองแบบ cache ผลต่อ pc ไว้ จุดเรียกเดิมจึงไม่ต้อง symbolize ซ้ำ
+func (p *Provider) logCaller() string {
 	const (
 		maxDepth   = 32
-		skipFrames = 3
+		skipFrames = 3 // runtime.Callers, logCaller, Send
 	)
-
-	pcs := make([]uintptr, maxDepth)
-	n := runtime.Callers(skipFrames, pcs)
-	if n == 0 {
+	cfg := &p.cfg
+	if cfg.DisableCaller {
 		return ""
 	}
 
-	frames := runtime.CallersFrames(pcs[:n])
+	if len(cfg.SkipCallerPkgs) == 0 && len(cfg.SkipCallerFiles) == 0 {
+		var pc [1]uintptr
+		if runtime.Callers(skipFrames+cfg.CallerSkip, pc[:]) == 0 {
+			return ""
+		}
+		return p.callerOf(pc[0]).caller
+	}
+
+	var pcs [maxDepth]uintptr
+	n := runtime.Callers(skipFrames, pcs[:])
+	for _, pc := range pcs[:n] {
+		if f := p.callerOf(pc); f.use {
+			return f.caller
+		}
+	}
+	return ""
+}
 
+func (p *Provider) callerOf(pc uintptr) callerFrame {
+	if v, ok := p.callers.Load(pc); ok {
+		return v.(callerFrame)
+	}
+
+	var f callerFrame
+	frames := runtime.CallersFrames([]uintptr{pc})
 	for {
+		// pc เดียวอาจมีหลาย frame เมื่อฟังก์ชันถูก inline
 		frame, more := frames.Next()
-
-		if useFrame(frame) {
-			file := filepath.Base(frame.File)
-			funcName := shortFuncName(frame.Function)
-			return fmt.Sprintf("%s:%d %s", file, frame.Line, funcName)
+		if useFrame(&p.cfg, frame) {
+			f = callerFrame{
+				use:    true,
+				caller: fmt.Sprintf("%s:%d %s", filepath.Base(frame.File), frame.Line, shortFuncName(frame.Function)),
+			}
+			break
 		}
-
 		if !more {
 			break
 		}
 	}
-
-	return ""
+	p.callers.Store(pc, f)
+	return f
 }
 
-func useFrame(frame runtime.Frame) bool {
+func useFrame(cfg *Config, frame runtime.Frame) bool {
 	if frame.File == "" && frame.Function == "" {
 		return false
 	}
 
-	for _, p := range globalCfg.SkipCallerPkgs {
+	for _, p := range cfg.SkipCallerPkgs {
 		if p != "" && strings.HasPrefix(frame.Function, p) {
 			return false
 		}
 	}
 
-	for _, f := range globalCfg.SkipCallerFiles {
+	for _, f := range cfg.SkipCallerFiles {
 		if f != "" && strings.Contains(frame.File, f) {
 			return false
 		}
@@ -170,6 +264,10 @@ func shortFuncName(fn string) string {
 }
 
 func (b *LogBuilder) Send() {
+	level := b.zapLevel()
+	if !b.pv.logEnabled(level) {
+		return
+	}
 	ctx := b.ctx
 	if ctx == nil {
 		ctx = context.Background()
@@ -179,11 +277,37 @@ func (b *LogBuilder) Send() {
 		msg = "no-message"
 	}
 
+	p := b.pv
+	if !p.logSampler.allow(level, msg, p.now()) {
+		return
+	}
 	span := trace.SpanFromContext(ctx)
 	sc := span.SpanContext()
 
+	if b.worker {
+		workerID, gid := workerInfo(ctx)
+		if workerID != "" {
+			b.fields = append(b.fields, zap.String("worker.id", workerID))
+		}
+		b.fields = append(b.fields, zap.Int64("goroutine.id", gid))
+	}
+	if len(p.cfg.LogBaggageKeys) > 0 {
+		b.fields = appendBaggageFields(b.fields, p.cfg.LogBaggageKeys, baggage.FromContext(ctx))
+	}
+	b.fields = p.hashFields(b.fields)
+
+	caller := b.caller
+	if caller == "" {
+		caller = p.logCaller()
+	}
+
+	var stack string
+	if b.stack {
+		stack = zap.StackSkip("", 1).String
+	}
+
 	// ====== OTEL Logs ======
-	if globalOtelLogger != nil {
+	if p.otelLogger != nil && p.otelLevel.Enabled(level) {
 		var rec otellog.Record
 
 		rec.SetSeverity(b.otelSeverity())
@@ -193,6 +317,15 @@ func (b *LogBuilder) Send() {
 		for _, a := range zapFieldsToOtelAttrs(b.fields) {
 			rec.AddAttributes(a)
 		}
+		if b.err != nil {
+			rec.AddAttributes(
+				otellog.String("exception.message", b.err.Error()),
+				otellog.String("exception.type", errorType(b.err)),
+			)
+		}
+		if stack != "" {
+			rec.AddAttributes(otellog.String("exception.stacktrace", stack))
+		}
 
 		// trace/span id
 		if sc.IsValid() {
@@ -203,22 +336,47 @@ func (b *LogBuilder) Send() {
 		}
 
 		// caller
-		if caller := logCaller(); caller != "" {
+		if caller != "" {
 			rec.AddAttributes(otellog.String("caller", caller))
 		}
 
-		now := time.Now().UTC()
+		now := p.now().UTC()
 		rec.SetTimestamp(now)
 		rec.SetObservedTimestamp(now)
 
-		globalOtelLogger.Emit(ctx, rec)
+		p.otelLogger.Emit(ctx, rec)
+	}
+
+	// ====== Span event ======
+	if p.cfg.LogsAsSpanEvents && span.IsRecording() {
+		attrs := make([]attribute.KeyValue, 0, len(b.fields)+4)
+		attrs = append(attrs,
+			attribute.String("log.severity", b.severityText()),
+			attribute.String("log.message", msg),
+		)
+		attrs = append(attrs, zapFieldsToSpanAttrs(b.fields)...)
+		if b.err != nil {
+			attrs = append(attrs,
+				// span ใช้ sanitizer เดียวกับ recordError (OTEL log record ยังเก็บข้อความเต็ม)
+				attribute.String("exception.message", p.statusDescription(b.err)),
+				attribute.String("exception.type", errorType(b.err)),
+			)
+		}
+		span.AddEvent("log", trace.WithAttributes(attrs...), trace.WithTimestamp(p.now()))
 	}
 
 	// ====== Zap logger ======
-	if globalLogger == nil {
+	if p.logger == nil {
 		return
 	}
 
+	if b.err != nil {
+		b.fields = append(b.fields, zap.Error(b.err), zap.String("error.type", errorType(b.err)))
+	}
+	if stack != "" {
+		b.fields = append(b.fields, zap.String("stacktrace", stack))
+	}
+
 	if sc.IsValid() {
 		b.fields = append(b.fields,
 			zap.String("trace_id", sc.TraceID().String()),
@@ -226,22 +384,76 @@ func (b *LogBuilder) Send() {
 		)
 	}
 
-	if caller := logCaller(); caller != "" {
+	if caller != "" {
 		b.fields = append(b.fields, zap.String("caller", caller))
 	}
 
+	// OTEL log ได้ tenant.id จาก tenantLogRouter แล้ว ตรงนี้เติมให้ zap
+	if tenant := p.tenantOf(ctx); tenant != "" {
+		b.fields = append(b.fields, zap.String(tenantAttrKey, tenant))
+	}
+
 	switch b.level {
 	case levelDebug:
-		globalLogger.Debug(msg, b.fields...)
+		p.logger.Debug(msg, b.fields...)
 	case levelInfo:
-		globalLogger.Info(msg, b.fields...)
+		p.logger.Info(msg, b.fields...)
 	case levelWarn:
-		globalLogger.Warn(msg, b.fields...)
+		p.logger.Warn(msg, b.fields...)
 	case levelError:
-		globalLogger.Error(msg, b.fields...)
+		p.logger.Error(msg, b.fields...)
 	}
 }
 
+// appendBaggageFields เติม baggage ที่อยู่ใน allow-list เป็น field ชื่อเดียวกับ key
+// key ที่ลงท้ายด้วย "*" = prefix เช่น "enduser.claim.*"
+func appendBaggageFields(fields []zap.Field, keys []string, bag baggage.Baggage) []zap.Field {
+	if bag.Len() == 0 {
+		return fields
+	}
+	for _, k := range keys {
+		if prefix, ok := strings.CutSuffix(k, "*"); ok {
+			for _, m := range bag.Members() {
+				if strings.HasPrefix(m.Key(), prefix) {
+					fields = append(fields, zap.String(m.Key(), m.Value()))
+				}
+			}
+			continue
+		}
+		if v := bag.Member(k).Value(); v != "" {
+			fields = append(fields, zap.String(k, v))
+		}
+	}
+	return fields
+}
+
+// zapFieldsToSpanAttrs เหมือน zapFieldsToOtelAttrs แต่เป็น attribute ของ span event
+func zapFieldsToSpanAttrs(fields []zap.Field) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(fields))
+
+	for _, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			attrs = append(attrs, attribute.String(f.Key, f.String))
+		case zapcore.BoolType:
+			attrs = append(attrs, attribute.Bool(f.Key, f.Integer == 1))
+		case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type,
+			zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type, zapcore.TimeType:
+			attrs = append(attrs, attribute.Int64(f.Key, f.Integer))
+		case zapcore.Float64Type:
+			attrs = append(attrs, attribute.Float64(f.Key, math.Float64frombits(uint64(f.Integer))))
+		case zapcore.Float32Type:
+			attrs = append(attrs, attribute.Float64(f.Key, float64(math.Float32frombits(uint32(f.Integer)))))
+		default:
+			if f.String != "" {
+				attrs = append(attrs, attribute.String(f.Key, f.String))
+			}
+		}
+	}
+
+	return attrs
+}
+
 func zapFieldsToOtelAttrs(fields []zap.Field) []otellog.KeyValue {
 	attrs := make([]otellog.KeyValue, 0, len(fields))
 
@@ -254,6 +466,10 @@ func zapFieldsToOtelAttrs(fields []zap.Field) []otellog.KeyValue {
 		case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type,
 			zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
 			attrs = append(attrs, otellog.Int64(f.Key, f.Integer))
+		case zapcore.Float64Type:
+			attrs = append(attrs, otellog.Float64(f.Key, math.Float64frombits(uint64(f.Integer))))
+		case zapcore.Float32Type:
+			attrs = append(attrs, otellog.Float64(f.Key, float64(math.Float32frombits(uint32(f.Integer)))))
 		case zapcore.TimeType:
 			attrs = append(attrs, otellog.Int64(f.Key, f.Integer))
 		default:
@@ -0,0 +1,188 @@
+package eto
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// ค่าของ LogDestination.Kind
+const (
+	LogDestinationConsole = "console" // ลง stderr ตาม Config.LogEncoding
+	LogDestinationFile    = "file"    // JSON ต่อท้ายไฟล์ที่ Path
+	LogDestinationOTEL    = "otel"    // OTEL logs ไป collector
+)
+
+// LogDestination ปลายทางของ eto.Log() หนึ่งที่ พร้อม level ขั้นต่ำของตัวเอง
+// เช่น ส่งเฉพาะ WARN ขึ้นไปเข้า OTEL แต่เก็บ debug ทั้งหมดไว้ในไฟล์ของเครื่อง
+type LogDestination struct {
+	Kind     string
+	Level    string      // "debug" / "info" / "warn" / "error", ค่าว่าง = ตาม LogLevel (เปลี่ยนได้ด้วย SetLogLevel)
+	Path     string      // Kind = file เท่านั้น
+	Rotation LogRotation // Kind = file เท่านั้น
+}
+
+// LogFileOutput ปลายทาง log ในเครื่องที่เขียนเพิ่มจาก stderr / OTLP เช่นเครื่องที่ต้องเก็บ log ไว้ตาม compliance
+type LogFileOutput struct {
+	Path     string // ว่าง = ไม่เขียนไฟล์
+	Level    string // ค่าว่าง = ตาม LogLevel (ใช้กับทั้งไฟล์และ Syslog)
+	Rotation LogRotation
+
+	// Syslog ส่ง log (JSON) เข้า syslog / journald ของเครื่องด้วย สำหรับ host ที่รวม log ผ่าน rsyslog (nil = ไม่ส่ง)
+	Syslog *SyslogOutput
+}
+
+// SyslogOutput ปลายทาง syslog (Network / Addr ว่าง = socket ของเครื่อง เช่น /dev/log ที่ journald รับ)
+type SyslogOutput struct {
+	Network string // "udp" / "tcp" สำหรับ syslog server ภายนอก
+	Addr    string // เช่น "syslog.internal:514"
+	Tag     string // ค่าว่าง = ServiceName
+}
+
+// LogRotation หมุนไฟล์ log ตามขนาด / อายุ (ทุกค่าเป็น 0 = เขียนไฟล์เดียวต่อท้ายไปเรื่อย ๆ)
+type LogRotation struct {
+	MaxSizeMB  int  // ขนาดไฟล์ปัจจุบันก่อนหมุน (0 = 100MB เมื่อเปิด rotation)
+	MaxAgeDays int  // ลบไฟล์ที่หมุนแล้วเก่ากว่านี้ (0 = ไม่ลบตามอายุ)
+	MaxBackups int  // จำนวนไฟล์เก่าที่เก็บ (0 = ไม่จำกัด)
+	Compress   bool // gzip ไฟล์ที่หมุนแล้ว
+}
+
+func (r LogRotation) enabled() bool {
+	return r != LogRotation{}
+}
+
+func (d LogDestination) validate() error {
+	switch d.Kind {
+	case LogDestinationConsole, LogDestinationOTEL:
+	case LogDestinationFile:
+		if d.Path == "" {
+			return fmt.Errorf("%w: LogDestinations: file destination requires Path", ErrInvalidConfig)
+		}
+	default:
+		return fmt.Errorf("%w: LogDestinations: unknown kind %q", ErrInvalidConfig, d.Kind)
+	}
+	if d.Level != "" {
+		if _, err := zapcore.ParseLevel(d.Level); err != nil {
+			return fmt.Errorf("%w: LogDestinations: %s: %w", ErrInvalidConfig, d.Kind, err)
+		}
+	}
+	return nil
+}
+
+// levelOf: destination ที่ไม่ได้ใส่ Level ใช้ level กลางของ provider (SetLogLevel มีผล)
+func (p *Provider) levelOf(d LogDestination) zapcore.LevelEnabler {
+	if d.Level == "" {
+		return p.logLevel
+	}
+	l, _ := zapcore.ParseLevel(d.Level)
+	return l
+}
+
+// neverLevel ใช้ปิด OTEL logs เมื่อ LogDestinations ไม่มีปลายทาง otel
+type neverLevel struct{}
+
+func (neverLevel) Enabled(zapcore.Level) bool { return false }
+
+// newDestinationLogger สร้าง zap logger (console / file) ตาม LogDestinations และตั้ง level ของ OTEL logs
+func (p *Provider) newDestinationLogger() (*zap.Logger, error) {
+	var cores []zapcore.Core
+	p.otelLevel = neverLevel{}
+
+	for _, d := range p.cfg.LogDestinations {
+		switch d.Kind {
+		case LogDestinationConsole:
+			cores = append(cores, zapcore.NewCore(consoleEncoder(p.cfg), zapcore.Lock(os.Stderr), p.levelOf(d)))
+		case LogDestinationFile:
+			core, err := p.fileCore(d.Path, p.levelOf(d), d.Rotation)
+			if err != nil {
+				return nil, err
+			}
+			cores = append(cores, core)
+		case LogDestinationOTEL:
+			p.otelLevel = p.levelOf(d)
+		}
+	}
+	return zap.New(zapcore.NewTee(cores...), zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)), nil
+}
+
+// fileCore เขียน JSON ลงไฟล์ (หมุนไฟล์แบบ lumberjack เมื่อกำหนด Rotation) ไฟล์ถูกปิดตอน Shutdown
+func (p *Provider) fileCore(path string, level zapcore.LevelEnabler, rot LogRotation) (zapcore.Core, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("eto: log file %s: %w", path, err)
+	}
+
+	var w zapcore.WriteSyncer
+	if rot.enabled() {
+		lj := &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    rot.MaxSizeMB,
+			MaxAge:     rot.MaxAgeDays,
+			MaxBackups: rot.MaxBackups,
+			Compress:   rot.Compress,
+		}
+		p.closers = append(p.closers, lj)
+		w = zapcore.AddSync(lj)
+	} else {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("eto: log file %s: %w", path, err)
+		}
+		p.closers = append(p.closers, f)
+		w = f
+	}
+	return zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.Lock(w), level), nil
+}
+
+// withLogOutput tee ไฟล์ / syslog ของ Config.LogOutput เข้ากับ logger ที่สร้างไว้แล้ว
+func (p *Provider) withLogOutput(logger *zap.Logger) (*zap.Logger, error) {
+	out := p.cfg.LogOutput
+	level := p.levelOf(LogDestination{Level: out.Level})
+
+	var cores []zapcore.Core
+	if out.Path != "" {
+		core, err := p.fileCore(out.Path, level, out.Rotation)
+		if err != nil {
+			return nil, err
+		}
+		cores = append(cores, core)
+	}
+	if out.Syslog != nil {
+		core, err := p.syslogCore(out.Syslog, level)
+		if err != nil {
+			return nil, err
+		}
+		cores = append(cores, core)
+	}
+	if len(cores) == 0 {
+		return logger, nil
+	}
+	return logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(append([]zapcore.Core{c}, cores...)...)
+	})), nil
+}
+
+// consoleEncoder: json แบบ production หรือแบบ zap.NewDevelopment (level มีสี, เวลาอ่านง่าย) ตาม LogEncoding
+func consoleEncoder(cfg Config) zapcore.Encoder {
+	if cfg.logEncoding() == LogEncodingConsole {
+		return zapcore.NewConsoleEncoder(developmentEncoderConfig())
+	}
+	return zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+}
+
+func developmentEncoderConfig() zapcore.EncoderConfig {
+	enc := zap.NewDevelopmentEncoderConfig()
+	enc.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	return enc
+}
+
+// logEnabled: มีปลายทางไหนรับ level นี้บ้าง (ไม่มีเลย = ไม่ต้องสร้าง record)
+func (p *Provider) logEnabled(l zapcore.Level) bool {
+	if p.otelLogger != nil && p.otelLevel.Enabled(l) {
+		return true
+	}
+	return p.logger != nil && p.logger.Core().Enabled(l)
+}
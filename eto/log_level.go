@@ -0,0 +1,58 @@
+package eto
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newLogLevel สร้าง level ตั้งต้นจาก Config.LogLevel (ค่าว่าง / อ่านไม่ออก = info เหมือน zap.NewProduction)
+func newLogLevel(cfg Config) zap.AtomicLevel {
+	lvl := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	if cfg.LogLevel != "" {
+		_ = lvl.UnmarshalText([]byte(cfg.LogLevel))
+	}
+	return lvl
+}
+
+// SetLogLevel เปลี่ยน level ของ default provider ทันทีโดยไม่ต้อง restart ("debug", "info", "warn", "error")
+// มีผลทั้ง zap logger และ NewZapCore ส่วน OTEL logs ของ eto.Log() มีผลเมื่อตั้ง Config.LogLevel ไว้
+// (หรือปลายทาง otel ใน LogDestinations ไม่ได้ใส่ Level)
+func SetLogLevel(level string) error {
+	return std().SetLogLevel(level)
+}
+
+// SetLogLevel ของ Provider ตัวนี้ (ดู eto.SetLogLevel)
+func (p *Provider) SetLogLevel(level string) error {
+	l, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("eto.SetLogLevel: %w", err)
+	}
+	p.logLevel.SetLevel(l)
+	return nil
+}
+
+// LogLevelHandler: endpoint ดู / เปลี่ยน log level ของ default provider ตอน runtime (รูปแบบเดียวกับ zap.AtomicLevel)
+// GET → {"level":"info"}, PUT body {"level":"debug"} หรือ form level=debug
+// endpoint นี้เปลี่ยนพฤติกรรมของ process ได้ ควรเปิดเฉพาะ port ภายใน / หลัง auth
+// ใช้แบบ: mux.Handle("/debug/loglevel", eto.LogLevelHandler())
+func LogLevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		std().logLevel.ServeHTTP(w, r)
+	})
+}
+
+func (b *LogBuilder) zapLevel() zapcore.Level {
+	switch b.level {
+	case levelDebug:
+		return zapcore.DebugLevel
+	case levelWarn:
+		return zapcore.WarnLevel
+	case levelError:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
@@ -0,0 +1,117 @@
+package eto
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LogSampling กฎ sampling ของ log หนึ่ง level แบบ zap sampler: ต่อข้อความเดียวกันในแต่ละช่วง Tick
+// ส่ง Initial รายการแรก จากนั้นส่ง 1 ใน Thereafter (0 = ทิ้งที่เหลือทั้งหมด)
+// ตัดสินครั้งเดียวใน Send จึงมีผลทั้ง zap และ OTEL logs พร้อมกัน
+type LogSampling struct {
+	Tick       time.Duration // 0 = 1 วินาที
+	Initial    int
+	Thereafter int
+}
+
+func (s LogSampling) validate(level string) error {
+	if _, err := zapcore.ParseLevel(level); err != nil {
+		return fmt.Errorf("%w: LogSampling: %w", ErrInvalidConfig, err)
+	}
+	if s.Tick < 0 || s.Initial < 0 || s.Thereafter < 0 {
+		return fmt.Errorf("%w: LogSampling[%s]: values must be >= 0", ErrInvalidConfig, level)
+	}
+	return nil
+}
+
+// logSamplerBuckets จำนวนตัวนับต่อ level (ข้อความที่ hash ชนกันนับรวมกัน แบบเดียวกับ zap)
+const logSamplerBuckets = 4096
+
+type logSampler struct {
+	rules [zapcore.FatalLevel - zapcore.DebugLevel + 1]*sampleRule
+}
+
+type sampleRule struct {
+	tick       int64
+	initial    uint64
+	thereafter uint64
+	counts     [logSamplerBuckets]sampleCounter
+}
+
+type sampleCounter struct {
+	resetAt atomic.Int64
+	n       atomic.Uint64
+}
+
+// newLogSampler nil = ไม่มีกฎ (ไม่ sample)
+func newLogSampler(cfg Config) *logSampler {
+	if len(cfg.LogSampling) == 0 {
+		return nil
+	}
+	s := &logSampler{}
+	for name, rule := range cfg.LogSampling {
+		lvl, err := zapcore.ParseLevel(name)
+		if err != nil || lvl < zapcore.DebugLevel || lvl > zapcore.FatalLevel {
+			continue
+		}
+		tick := rule.Tick
+		if tick <= 0 {
+			tick = time.Second
+		}
+		s.rules[lvl-zapcore.DebugLevel] = &sampleRule{
+			tick:       int64(tick),
+			initial:    uint64(rule.Initial),
+			thereafter: uint64(rule.Thereafter),
+		}
+	}
+	return s
+}
+
+// allow ตัดสินว่า record (level, msg) ณ เวลา now ผ่าน sampling หรือไม่
+func (s *logSampler) allow(lvl zapcore.Level, msg string, now time.Time) bool {
+	if s == nil || lvl < zapcore.DebugLevel || lvl > zapcore.FatalLevel {
+		return true
+	}
+	r := s.rules[lvl-zapcore.DebugLevel]
+	if r == nil {
+		return true
+	}
+
+	c := &r.counts[fnv32a(msg)%logSamplerBuckets]
+
+	n := c.inc(now.UnixNano(), r.tick)
+	if n <= r.initial {
+		return true
+	}
+	return r.thereafter > 0 && (n-r.initial)%r.thereafter == 0
+}
+
+// fnv32a แบบไม่ allocate (hash/fnv ต้องแปลง string เป็น []byte)
+func fnv32a(s string) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	h := uint32(offset32)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= prime32
+	}
+	return h
+}
+
+// inc นับเพิ่มแล้วคืนลำดับในช่วง tick ปัจจุบัน (ขึ้นช่วงใหม่ = เริ่มนับ 1)
+func (c *sampleCounter) inc(now, tick int64) uint64 {
+	resetAt := c.resetAt.Load()
+	if now < resetAt {
+		return c.n.Add(1)
+	}
+	if c.resetAt.CompareAndSwap(resetAt, now+tick) {
+		c.n.Store(1)
+		return 1
+	}
+	return c.n.Add(1)
+}
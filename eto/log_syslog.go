@@ -0,0 +1,73 @@
+//go:build !windows && !plan9
+
+package eto
+
+import (
+	"fmt"
+	"log/syslog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// syslogCore เขียน record (JSON) ลง syslog ด้วย priority ตาม level
+// journald รับจาก socket เดียวกัน (/dev/log) จึงใช้ได้ทั้ง rsyslog และ journald
+type syslogCore struct {
+	zapcore.LevelEnabler
+	enc zapcore.Encoder
+	w   *syslog.Writer
+}
+
+func (p *Provider) syslogCore(out *SyslogOutput, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	tag := firstNonEmpty(out.Tag, p.cfg.ServiceName)
+	w, err := syslog.Dial(out.Network, out.Addr, syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, fmt.Errorf("eto: syslog %s %s: %w", out.Network, out.Addr, err)
+	}
+	p.closers = append(p.closers, w)
+
+	enc := zap.NewProductionEncoderConfig()
+	// syslog / journald ใส่เวลาให้เองอยู่แล้ว
+	enc.TimeKey = ""
+	return &syslogCore{LevelEnabler: level, enc: zapcore.NewJSONEncoder(enc), w: w}, nil
+}
+
+func (c *syslogCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+	}
+	return &clone
+}
+
+func (c *syslogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *syslogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	msg := buf.String()
+	buf.Free()
+
+	switch {
+	case ent.Level >= zapcore.DPanicLevel:
+		return c.w.Crit(msg)
+	case ent.Level >= zapcore.ErrorLevel:
+		return c.w.Err(msg)
+	case ent.Level >= zapcore.WarnLevel:
+		return c.w.Warning(msg)
+	case ent.Level >= zapcore.InfoLevel:
+		return c.w.Info(msg)
+	default:
+		return c.w.Debug(msg)
+	}
+}
+
+func (c *syslogCore) Sync() error { return nil }
@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package eto
+
+import (
+	"errors"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func (p *Provider) syslogCore(*SyslogOutput, zapcore.LevelEnabler) (zapcore.Core, error) {
+	return nil, errors.New("eto: syslog is not supported on this platform")
+}
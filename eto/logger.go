@@ -0,0 +1,64 @@
+package eto
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Logger logger ลูกที่มี field (และ ctx) ตั้งไว้ล่วงหน้า ทุก record ที่สร้างจาก Log() จะได้ field เหล่านี้ด้วย
+// เช่น
+//
+//	billing := eto.Log().FromContext(ctx).With("component", "billing")
+//	billing.Log().Info().Msg("charged").Field("amount", 100).Send()
+//
+// Logger ไม่เปลี่ยนค่าหลังสร้าง ใช้ข้าม goroutine ได้
+type Logger struct {
+	pv     *Provider
+	ctx    context.Context
+	fields []zap.Field
+}
+
+// With สร้าง Logger จาก ctx + field ที่ builder มีอยู่ตอนนี้ แล้วเพิ่ม key / val
+func (b *LogBuilder) With(key string, val any) *Logger {
+	l := &Logger{pv: b.pv, ctx: b.ctx}
+	return l.with(b.fields, anyField(key, val))
+}
+
+// WithFields สร้าง Logger จาก ctx + field ที่ builder มีอยู่ตอนนี้ แล้วเพิ่ม fields
+func (b *LogBuilder) WithFields(fields ...zap.Field) *Logger {
+	l := &Logger{pv: b.pv, ctx: b.ctx}
+	return l.with(b.fields, fields...)
+}
+
+// With คืน Logger ตัวใหม่ที่มี field เพิ่ม (ตัวเดิมไม่เปลี่ยน)
+func (l *Logger) With(key string, val any) *Logger {
+	return l.with(l.fields, anyField(key, val))
+}
+
+// WithFields เหมือน With แต่รับ zap.Field
+func (l *Logger) WithFields(fields ...zap.Field) *Logger {
+	return l.with(l.fields, fields...)
+}
+
+// WithContext คืน Logger ตัวใหม่ที่ผูกกับ ctx (เช่น ctx ของ request ที่มี span)
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	if ctx == nil {
+		return l
+	}
+	return &Logger{pv: l.pv, ctx: ctx, fields: l.fields}
+}
+
+// Log สร้าง LogBuilder ที่มี ctx และ field ของ Logger ใส่ไว้แล้ว
+func (l *Logger) Log() *LogBuilder {
+	b := l.pv.Log().FromContext(l.ctx)
+	// cap = len กัน append ของ builder ไปเขียนทับ slice ที่ Logger ใช้ร่วมกัน
+	b.fields = l.fields[:len(l.fields):len(l.fields)]
+	return b
+}
+
+func (l *Logger) with(base []zap.Field, extra ...zap.Field) *Logger {
+	fields := make([]zap.Field, 0, len(base)+len(extra))
+	fields = append(append(fields, base...), extra...)
+	return &Logger{pv: l.pv, ctx: l.ctx, fields: fields}
+}
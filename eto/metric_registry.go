@@ -0,0 +1,107 @@
+package eto
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	metricKindCounter   = "counter"
+	metricKindHistogram = "histogram"
+	metricKindGauge     = "gauge"
+)
+
+// metricDef นิยามของ instrument หนึ่งชื่อ (kind ว่าง = มาจาก DescribeMetric แต่ยังไม่ถูกสร้าง)
+type metricDef struct {
+	kind string
+	unit string
+	desc string
+}
+
+// describedMetrics นิยามจาก eto.DescribeMetric ระดับ package ที่ New คัดลอกไปให้ Provider ใหม่
+// (DescribeMetric ใน init ของ package เกิดก่อน Init จึงเก็บไว้ใน Provider ไม่ได้)
+var (
+	describedMu      sync.Mutex
+	describedMetrics = map[string]metricDef{}
+)
+
+// DescribeMetric: ลงทะเบียน unit / description ของ metric ไว้ที่เดียว (เช่นใน init ของ package metrics)
+// builder ที่ไม่ได้ใส่ Unit / Description เองจะใช้ค่านี้ ถ้ามีที่ไหนใส่ค่าไม่ตรงจะ log warning ครั้งเดียวต่อชื่อ
+// เรียกก่อนหรือหลัง Init ก็ได้ แต่ต้องก่อนบันทึก metric ชื่อนั้นครั้งแรก ไม่งั้น instrument ถูกสร้างด้วยค่าของ builder ไปแล้ว
+func DescribeMetric(name, unit, desc string) {
+	describedMu.Lock()
+	if _, ok := describedMetrics[name]; !ok {
+		describedMetrics[name] = metricDef{unit: unit, desc: desc}
+	}
+	describedMu.Unlock()
+
+	std().DescribeMetric(name, unit, desc)
+}
+
+// describedMetricDefs คืนสำเนานิยามจาก eto.DescribeMetric สำหรับ Provider ใหม่
+func describedMetricDefs() map[string]metricDef {
+	describedMu.Lock()
+	defer describedMu.Unlock()
+	defs := make(map[string]metricDef, len(describedMetrics))
+	for name, def := range describedMetrics {
+		defs[name] = def
+	}
+	return defs
+}
+
+// DescribeMetric ของ Provider ตัวนี้ (ดู eto.DescribeMetric)
+func (p *Provider) DescribeMetric(name, unit, desc string) {
+	p.defMu.Lock()
+	defer p.defMu.Unlock()
+
+	if def, ok := p.metricDefs[name]; ok {
+		if def.unit != unit || def.desc != desc {
+			p.warnMetricConflict(name, def, metricDef{kind: def.kind, unit: unit, desc: desc})
+		}
+		return
+	}
+	p.metricDefs[name] = metricDef{unit: unit, desc: desc}
+}
+
+// resolveMetricDef คืนนิยามที่ใช้สร้าง instrument: ค่าที่ลงทะเบียน / สร้างไว้ก่อนชนะเสมอ
+// (instrument cache ตามชื่อ) ส่วนค่าที่ขัดกันจะถูก log warning ครั้งเดียวต่อชื่อ
+func (p *Provider) resolveMetricDef(name, kind, unit, desc string) metricDef {
+	p.defMu.Lock()
+	defer p.defMu.Unlock()
+
+	def, ok := p.metricDefs[name]
+	if !ok {
+		def = metricDef{kind: kind, unit: unit, desc: desc}
+		p.metricDefs[name] = def
+		return def
+	}
+	if def.kind == "" {
+		def.kind = kind
+		p.metricDefs[name] = def
+	}
+
+	if def.kind != kind || (unit != "" && unit != def.unit) || (desc != "" && desc != def.desc) {
+		p.warnMetricConflict(name, def, metricDef{kind: kind, unit: unit, desc: desc})
+	}
+	return def
+}
+
+func (p *Provider) warnMetricConflict(name string, have, got metricDef) {
+	// ยังไม่มี logger (ก่อน Init) ไม่ต้องจำว่า warn แล้ว ให้ provider จริง warn ได้ภายหลัง
+	if p.logger == nil {
+		return
+	}
+	if _, warned := p.metricWarned.LoadOrStore(name, struct{}{}); warned {
+		return
+	}
+	p.logger.Warn("eto: conflicting metric definition (keeping the first)",
+		zap.String("metric", name),
+		zap.String("kind", have.kind),
+		zap.String("unit", have.unit),
+		zap.String("description", have.desc),
+		zap.String("conflicting_kind", got.kind),
+		zap.String("conflicting_unit", got.unit),
+		zap.String("conflicting_description", got.desc),
+	)
+}
@@ -3,20 +3,17 @@ package eto
 import (
 	"context"
 	"fmt"
-	"sync"
+	"sort"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
-)
-
-var (
-	counterMu      sync.Mutex
-	counterCache   = map[string]metric.Int64Counter{}
-	histogramMu    sync.Mutex
-	histogramCache = map[string]metric.Float64Histogram{}
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type CounterBuilder struct {
+	pv    *Provider
 	name  string
 	attrs []attribute.KeyValue
 	unit  string
@@ -24,9 +21,14 @@ type CounterBuilder struct {
 }
 
 func MetricCounter(name string) *CounterBuilder {
+	return std().MetricCounter(name)
+}
+
+// MetricCounter สร้าง counter builder ที่ส่ง metric ผ่าน Provider ตัวนี้
+func (p *Provider) MetricCounter(name string) *CounterBuilder {
 	return &CounterBuilder{
+		pv:   p,
 		name: name,
-		unit: "1",
 	}
 }
 
@@ -52,52 +54,80 @@ func (b *CounterBuilder) Description(desc string) *CounterBuilder {
 	return b
 }
 
+// backgroundCtx ใช้กับการบันทึกที่ไม่มี ctx (ไม่มี span → ไม่ต้องหา exemplar / tenant)
+var backgroundCtx = context.Background()
+
+// Add0 = Add แบบไม่มี ctx สำหรับ goroutine เบื้องหลังที่ไม่ได้อยู่ใน request
+func (b *CounterBuilder) Add0(value int64) {
+	b.Add(nil, value)
+}
+
+// Add เพิ่มค่า counter (ctx เป็น nil ได้ = ไม่มี trace / tenant)
 func (b *CounterBuilder) Add(ctx context.Context, value int64) {
-	if !globalCfg.EnableMetrics || globalMeter == nil {
+	p := b.pv
+	if !p.cfg.EnableMetrics || p.meter == nil {
 		return
 	}
 
-	counter := getOrCreateCounter(b.name, b.unit, b.desc)
+	counter := p.getOrCreateCounter(b.name, b.unit, b.desc)
 	if counter == nil {
 		// สร้าง instrument ไม่ได้ → ไม่ต้องทำอะไร
 		return
 	}
 
-	counter.Add(ctx, value, metric.WithAttributes(b.attrs...))
+	attrs := p.cardinality.apply(p, b.name, p.metricAttrs(ctx, b.attrs))
+	if ctx == nil {
+		ctx = backgroundCtx
+	}
+	counter.Add(ctx, value, metric.WithAttributes(attrs...))
 }
 
-func getOrCreateCounter(name, unit, desc string) metric.Int64Counter {
-	counterMu.Lock()
-	defer counterMu.Unlock()
-
-	if c, ok := counterCache[name]; ok {
-		return c
+func (p *Provider) getOrCreateCounter(name, unit, desc string) metric.Int64Counter {
+	c, evicted, ok := p.counters.getOrCreate(name, func() {
+		if unit != "" || desc != "" {
+			p.resolveMetricDef(name, metricKindCounter, unit, desc)
+		}
+	}, func() (metric.Int64Counter, bool) {
+		def := p.resolveMetricDef(name, metricKindCounter, unit, desc)
+		c, err := p.meter.Int64Counter(
+			name,
+			metric.WithUnit(firstNonEmpty(def.unit, "1")),
+			metric.WithDescription(def.desc),
+		)
+		if err != nil {
+			// อย่า panic / log ซ้ำไปซ้ำมา แค่ไม่ส่ง metric พอ (Health() จะรายงานให้)
+			p.setInstrumentErr(fmt.Errorf("eto: create counter %q: %w", name, err))
+			return nil, false
+		}
+		return c, true
+	})
+	if evicted != "" {
+		p.instrumentEvicted(metricKindCounter, evicted)
 	}
-
-	c, err := globalMeter.Int64Counter(
-		name,
-		metric.WithUnit(unit),
-		metric.WithDescription(desc),
-	)
-	if err != nil {
-		// อย่า panic / log ซ้ำไปซ้ำมา แค่ไม่ส่ง metric พอ
+	if !ok {
 		return nil
 	}
-	counterCache[name] = c
 	return c
 }
 
 type HistogramBuilder struct {
-	name  string
-	attrs []attribute.KeyValue
-	unit  string
-	desc  string
+	pv      *Provider
+	name    string
+	attrs   []attribute.KeyValue
+	unit    string
+	desc    string
+	buckets []float64
 }
 
 func MetricHistogram(name string) *HistogramBuilder {
+	return std().MetricHistogram(name)
+}
+
+// MetricHistogram สร้าง histogram builder ที่ส่ง metric ผ่าน Provider ตัวนี้
+func (p *Provider) MetricHistogram(name string) *HistogramBuilder {
 	return &HistogramBuilder{
+		pv:   p,
 		name: name,
-		unit: "ms",
 	}
 }
 
@@ -123,39 +153,110 @@ func (b *HistogramBuilder) Description(desc string) *HistogramBuilder {
 	return b
 }
 
+// Buckets กำหนด explicit bucket boundaries (มีผลตอนสร้าง instrument ครั้งแรกของชื่อนี้เท่านั้น)
+// ถ้า Config.HistogramBuckets หรือ MetricViews กำหนดให้ชื่อเดียวกันไว้ ค่าจาก View จะชนะ
+func (b *HistogramBuilder) Buckets(bounds ...float64) *HistogramBuilder {
+	b.buckets = bounds
+	return b
+}
+
+// Record0 = Record แบบไม่มี ctx สำหรับ goroutine เบื้องหลังที่ไม่ได้อยู่ใน request
+func (b *HistogramBuilder) Record0(value float64) {
+	b.Record(nil, value)
+}
+
+// Record บันทึกค่า histogram (ctx เป็น nil ได้ = ไม่มี trace / tenant)
 func (b *HistogramBuilder) Record(ctx context.Context, value float64) {
-	if !globalCfg.EnableMetrics || globalMeter == nil {
+	p := b.pv
+	if !p.cfg.EnableMetrics || p.meter == nil {
 		return
 	}
 
-	h := getOrCreateHistogram(b.name, b.unit, b.desc)
+	h := p.getOrCreateHistogram(b.name, b.unit, b.desc, b.buckets)
 	if h == nil {
 		return
 	}
 
-	h.Record(ctx, value, metric.WithAttributes(b.attrs...))
+	attrs := p.cardinality.apply(p, b.name, p.metricAttrs(ctx, b.attrs))
+	if ctx == nil {
+		ctx = backgroundCtx
+	}
+	h.Record(ctx, value, metric.WithAttributes(attrs...))
 }
 
-func getOrCreateHistogram(name, unit, desc string) metric.Float64Histogram {
-	histogramMu.Lock()
-	defer histogramMu.Unlock()
-
-	if h, ok := histogramCache[name]; ok {
-		return h
+func (p *Provider) getOrCreateHistogram(name, unit, desc string, buckets []float64) metric.Float64Histogram {
+	h, evicted, ok := p.histograms.getOrCreate(name, func() {
+		if unit != "" || desc != "" {
+			p.resolveMetricDef(name, metricKindHistogram, unit, desc)
+		}
+	}, func() (metric.Float64Histogram, bool) {
+		def := p.resolveMetricDef(name, metricKindHistogram, unit, desc)
+		opts := []metric.Float64HistogramOption{
+			metric.WithUnit(firstNonEmpty(def.unit, "ms")),
+			metric.WithDescription(def.desc),
+		}
+		if len(buckets) > 0 {
+			opts = append(opts, metric.WithExplicitBucketBoundaries(buckets...))
+		}
+		h, err := p.meter.Float64Histogram(name, opts...)
+		if err != nil {
+			p.setInstrumentErr(fmt.Errorf("eto: create histogram %q: %w", name, err))
+			return nil, false
+		}
+		return h, true
+	})
+	if evicted != "" {
+		p.instrumentEvicted(metricKindHistogram, evicted)
 	}
-
-	h, err := globalMeter.Float64Histogram(
-		name,
-		metric.WithUnit(unit),
-		metric.WithDescription(desc),
-	)
-	if err != nil {
+	if !ok {
 		return nil
 	}
-	histogramCache[name] = h
 	return h
 }
 
+func (p *Provider) getOrCreateGauge(name, unit, desc string) metric.Float64Gauge {
+	g, evicted, ok := p.gauges.getOrCreate(name, nil, func() (metric.Float64Gauge, bool) {
+		def := p.resolveMetricDef(name, metricKindGauge, unit, desc)
+		g, err := p.meter.Float64Gauge(
+			name,
+			metric.WithUnit(def.unit),
+			metric.WithDescription(def.desc),
+		)
+		if err != nil {
+			p.setInstrumentErr(fmt.Errorf("eto: create gauge %q: %w", name, err))
+			return nil, false
+		}
+		return g, true
+	})
+	if evicted != "" {
+		p.instrumentEvicted(metricKindGauge, evicted)
+	}
+	if !ok {
+		return nil
+	}
+	return g
+}
+
+// metricViews รวม View จาก HistogramBuckets (sort ชื่อให้ลำดับคงที่) กับ MetricViews
+func metricViews(cfg Config) []sdkmetric.View {
+	names := make([]string, 0, len(cfg.HistogramBuckets))
+	for name := range cfg.HistogramBuckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	views := make([]sdkmetric.View, 0, len(names)+len(cfg.MetricViews))
+	for _, name := range names {
+		views = append(views, sdkmetric.NewView(
+			sdkmetric.Instrument{Name: name, Kind: sdkmetric.InstrumentKindHistogram},
+			sdkmetric.Stream{Aggregation: sdkmetric.AggregationExplicitBucketHistogram{
+				Boundaries: cfg.HistogramBuckets[name],
+			}},
+		))
+	}
+	return append(views, cfg.MetricViews...)
+}
+
 func anyToAttr(key string, val any) attribute.KeyValue {
 	switch v := val.(type) {
 	case string:
@@ -172,3 +273,98 @@ func anyToAttr(key string, val any) attribute.KeyValue {
 		return attribute.String(key, fmt.Sprintf("%v", v))
 	}
 }
+
+// SetDefaultMetricAttrs: attribute ที่ติดกับทุก Counter / Histogram ของ default provider
+// (ต่อจาก service / env ที่ใส่ให้อัตโนมัติจาก Config) เรียกซ้ำ = แทนที่ชุดเดิม
+// attribute ที่ builder ใส่เองด้วย key เดียวกันจะทับค่า default
+func SetDefaultMetricAttrs(attrs ...attribute.KeyValue) {
+	std().SetDefaultMetricAttrs(attrs...)
+}
+
+// SetDefaultMetricAttrs ของ Provider ตัวนี้ (ดู eto.SetDefaultMetricAttrs)
+func (p *Provider) SetDefaultMetricAttrs(attrs ...attribute.KeyValue) {
+	defaults := make([]attribute.KeyValue, 0, 2+len(attrs))
+	if p.cfg.ServiceName != "" {
+		defaults = append(defaults, attribute.String("service", p.cfg.ServiceName))
+	}
+	if p.cfg.Environment != "" {
+		defaults = append(defaults, attribute.String("env", p.cfg.Environment))
+	}
+	defaults = append(defaults, attrs...)
+	p.defaultMetricAttrs.Store(&defaults)
+}
+
+// metricAttrs = default attrs + attrs ของ builder + tenant.id (ถ้ามี TenantFromContext)
+// ลำดับนี้ทำให้ค่าที่ใส่ทีหลังชนะเมื่อ key ซ้ำ (attribute.NewSet เก็บตัวสุดท้าย)
+func (p *Provider) metricAttrs(ctx context.Context, attrs []attribute.KeyValue) []attribute.KeyValue {
+	var defaults []attribute.KeyValue
+	if d := p.defaultMetricAttrs.Load(); d != nil {
+		defaults = *d
+	}
+	tenant := p.tenantOf(ctx)
+
+	out := make([]attribute.KeyValue, 0, len(defaults)+len(attrs)+1)
+	out = append(out, defaults...)
+	// ต่อจาก default แต่ก่อน attrs ของ caller: key ซ้ำ ตัวหลังชนะ
+	out = p.appendSpanMetricAttrs(ctx, out)
+	out = append(out, attrs...)
+	if tenant != "" {
+		out = append(out, attribute.String(tenantAttrKey, tenant))
+	}
+	p.hashAttrs(out)
+	return out
+}
+
+func newSpanMetricKeys(cfg Config) map[attribute.Key]struct{} {
+	if len(cfg.MetricAttrsFromSpan) == 0 {
+		return nil
+	}
+	keys := make(map[attribute.Key]struct{}, len(cfg.MetricAttrsFromSpan))
+	for _, k := range cfg.MetricAttrsFromSpan {
+		keys[attribute.Key(k)] = struct{}{}
+	}
+	return keys
+}
+
+// appendSpanMetricAttrs เติม attribute ตาม Config.MetricAttrsFromSpan จาก span ที่ active ใน ctx
+// (อ่านได้เฉพาะ span ของ SDK ที่ยังไม่ End)
+func (p *Provider) appendSpanMetricAttrs(ctx context.Context, out []attribute.KeyValue) []attribute.KeyValue {
+	if len(p.spanMetricKeys) == 0 || ctx == nil {
+		return out
+	}
+	span, ok := trace.SpanFromContext(ctx).(interface{ Attributes() []attribute.KeyValue })
+	if !ok {
+		return out
+	}
+	for _, kv := range span.Attributes() {
+		if _, ok := p.spanMetricKeys[kv.Key]; ok {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
+
+// metricTemporality คืน selector ตาม Config.MetricTemporality
+// delta ใช้กับ counter / histogram เท่านั้น ส่วน up-down counter ต้องเป็น cumulative ไม่งั้นค่าจะไม่มีความหมาย
+func metricTemporality(cfg Config) sdkmetric.TemporalitySelector {
+	if cfg.MetricTemporality != MetricTemporalityDelta {
+		return sdkmetric.DefaultTemporalitySelector
+	}
+	return func(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+		switch kind {
+		case sdkmetric.InstrumentKindUpDownCounter, sdkmetric.InstrumentKindObservableUpDownCounter:
+			return metricdata.CumulativeTemporality
+		default:
+			return metricdata.DeltaTemporality
+		}
+	}
+}
+
+// newPeriodicReader ใช้ MetricExportInterval กับทุก push reader
+func newPeriodicReader(cfg Config, exp sdkmetric.Exporter) sdkmetric.Reader {
+	var opts []sdkmetric.PeriodicReaderOption
+	if cfg.MetricExportInterval > 0 {
+		opts = append(opts, sdkmetric.WithInterval(cfg.MetricExportInterval))
+	}
+	return sdkmetric.NewPeriodicReader(exp, opts...)
+}
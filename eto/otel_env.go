@@ -0,0 +1,163 @@
+package eto
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// env มาตรฐานของ OpenTelemetry ที่ eto อ่านตอน New / Init (ค่าใน Config ชนะเสมอเมื่อกำหนดไว้)
+const (
+	envSDKDisabled         = "OTEL_SDK_DISABLED"
+	envServiceName         = "OTEL_SERVICE_NAME"
+	envResourceAttributes  = "OTEL_RESOURCE_ATTRIBUTES"
+	envTracesSampler       = "OTEL_TRACES_SAMPLER"
+	envTracesSamplerArg    = "OTEL_TRACES_SAMPLER_ARG"
+	envOTLPEndpoint        = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envOTLPTracesEndpoint  = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	envOTLPMetricsEndpoint = "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"
+	envOTLPLogsEndpoint    = "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"
+	envOTLPHeaders         = "OTEL_EXPORTER_OTLP_HEADERS"
+	envPropagators         = "OTEL_PROPAGATORS"
+)
+
+// sdkDisabled: OTEL_SDK_DISABLED=true → New คืน provider ที่ไม่ต่อ collector (log ของ zap ยังออกตามปกติ)
+func sdkDisabled() bool {
+	v, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv(envSDKDisabled)))
+	return v
+}
+
+// applyOTelEnv เติม field ที่ว่างใน cfg จาก env มาตรฐานของ OTel ให้ ops คุมได้แบบเดียวกับ SDK ภาษาอื่น
+func applyOTelEnv(cfg Config) (Config, error) {
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = os.Getenv(envServiceName)
+	}
+	if cfg.OtelEndpoint == "" {
+		cfg.OtelEndpoint = otlpEndpointFromEnv(envOTLPEndpoint)
+	}
+	if cfg.TracesEndpoint == "" {
+		cfg.TracesEndpoint = otlpEndpointFromEnv(envOTLPTracesEndpoint)
+	}
+	if cfg.MetricsEndpoint == "" {
+		cfg.MetricsEndpoint = otlpEndpointFromEnv(envOTLPMetricsEndpoint)
+	}
+	if cfg.LogsEndpoint == "" {
+		cfg.LogsEndpoint = otlpEndpointFromEnv(envOTLPLogsEndpoint)
+	}
+
+	if env := parseEnvPairs(os.Getenv(envOTLPHeaders)); len(env) > 0 {
+		cfg.OTLPHeaders = mergeHeaders(env, cfg.OTLPHeaders)
+	}
+	if env := parseEnvPairs(os.Getenv(envResourceAttributes)); len(env) > 0 {
+		cfg.ResourceAttributes = mergeHeaders(env, cfg.ResourceAttributes)
+	}
+
+	if len(cfg.Propagators) == 0 {
+		cfg.Propagators, _ = propagatorsFromEnv()
+	}
+
+	if cfg.Sampler == nil {
+		s, err := samplerFromEnv(os.Getenv(envTracesSampler), os.Getenv(envTracesSamplerArg))
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Sampler = s
+	}
+	return cfg, nil
+}
+
+// propagatorsFromEnv อ่าน OTEL_PROPAGATORS: ชื่อที่ eto ไม่รองรับ (เช่น b3, jaeger) ถูกข้ามแล้วคืนใน unknown
+// ให้ warn ตาม spec ของ OTel แทนการทำให้ Init ล้ม ส่วน "none" = ไม่ propagate เลย
+func propagatorsFromEnv() (names, unknown []string) {
+	none := false
+	for _, name := range strings.Split(os.Getenv(envPropagators), ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case PropagatorNone:
+			none = true
+		case PropagatorTraceContext, PropagatorBaggage, PropagatorXRay:
+			names = append(names, name)
+		default:
+			unknown = append(unknown, name)
+		}
+	}
+	if none {
+		return []string{PropagatorNone}, unknown
+	}
+	return names, unknown
+}
+
+// otlpEndpointFromEnv: env ของ OTel เป็น URL (http://collector:4317) แต่ exporter gRPC ของ eto รับ host:port
+func otlpEndpointFromEnv(name string) string {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return ""
+	}
+	if u, err := url.Parse(v); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return v
+}
+
+// parseEnvPairs อ่านรูปแบบ "k1=v1,k2=v2" (ค่าเป็น URL-encoded ได้) ของ OTEL_EXPORTER_OTLP_HEADERS / OTEL_RESOURCE_ATTRIBUTES
+func parseEnvPairs(s string) map[string]string {
+	out := map[string]string{}
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		if dec, err := url.PathUnescape(v); err == nil {
+			v = dec
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// samplerFromEnv แปลง OTEL_TRACES_SAMPLER (+ _ARG สำหรับ ratio) เป็น sampler, ค่าว่าง = nil (ใช้ค่าเริ่มต้นของ eto)
+func samplerFromEnv(name, arg string) (sdktrace.Sampler, error) {
+	ratio := func() (float64, error) {
+		if strings.TrimSpace(arg) == "" {
+			return 1, nil
+		}
+		r, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+		if err != nil || r < 0 || r > 1 {
+			return 0, fmt.Errorf("%w: %s: invalid ratio %q", ErrInvalidConfig, envTracesSamplerArg, arg)
+		}
+		return r, nil
+	}
+
+	switch strings.TrimSpace(name) {
+	case "":
+		return nil, nil
+	case "always_on":
+		return sdktrace.AlwaysSample(), nil
+	case "always_off":
+		return sdktrace.NeverSample(), nil
+	case "traceidratio":
+		r, err := ratio()
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.TraceIDRatioBased(r), nil
+	case "parentbased_always_on":
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case "parentbased_always_off":
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case "parentbased_traceidratio":
+		r, err := ratio()
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(r)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s: unsupported sampler %q", ErrInvalidConfig, envTracesSampler, name)
+	}
+}
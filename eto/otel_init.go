@@ -2,10 +2,19 @@ package eto
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	otlploggrpc "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	otlpmetricgrpc "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	otlpgrpc "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	otellog "go.opentelemetry.io/otel/log"
 	logglobal "go.opentelemetry.io/otel/log/global"
@@ -16,120 +25,581 @@ import (
 
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
+// Provider รวม tracer / meter / logger / propagator ของ eto หนึ่งชุด
+// สร้างหลายตัวใน process เดียวได้ (เช่น ใน test หรือ binary ที่แยก tenant) ด้วย eto.New
+// ส่วน eto.Init จะสร้าง Provider แล้วตั้งเป็นตัว default ให้ API ระดับ package (eto.Trace(), eto.Log(), ...)
+type Provider struct {
+	cfg         Config
+	tp          *sdktrace.TracerProvider
+	mp          *sdkmetric.MeterProvider
+	lp          *sdklog.LoggerProvider
+	otelLogger  otellog.Logger
+	logger      *zap.Logger
+	propagator  propagation.TextMapPropagator
+	meter       metric.Meter
+	initialized bool
+	disabled    bool // สร้างตอน OTEL_SDK_DISABLED=true
+
+	counters     *instrumentCache[metric.Int64Counter]
+	histograms   *instrumentCache[metric.Float64Histogram]
+	gauges       *instrumentCache[metric.Float64Gauge]
+	evictionOnce sync.Once
+	evictions    metric.Int64Counter
+
+	metricsHandler http.Handler // มีเฉพาะ MetricsExporter = prometheus / both
+
+	defMu        sync.Mutex
+	metricDefs   map[string]metricDef
+	metricWarned sync.Map // ชื่อ metric ที่ warn เรื่องนิยามซ้ำ / cardinality ไปแล้ว
+	cardinality  *cardinalityGuard
+
+	defaultMetricAttrs atomic.Pointer[[]attribute.KeyValue]
+
+	logLevel       zap.AtomicLevel            // เปลี่ยนได้ตอน runtime ผ่าน SetLogLevel / LogLevelHandler
+	otelLevel      zapcore.LevelEnabler       // level ของ OTEL logs (= logLevel เมื่อตั้ง LogLevel, debug เมื่อไม่ตั้ง, หรือตาม LogDestinations)
+	logSampler     *logSampler                // Config.LogSampling (nil = ไม่ sample)
+	hashKeys       map[string]struct{}        // Config.HashAttrKeys
+	spanMetricKeys map[attribute.Key]struct{} // Config.MetricAttrsFromSpan
+	experiments    map[string]bool            // Config.Experiments + OTELGO_EXPERIMENTS
+
+	spanLoss lossCounter // pipeline หลักของ traces / logs ไว้รายงานตอน Shutdown
+	logLoss  lossCounter
+
+	pipeMu   sync.Mutex
+	queues   []*queueGuard                   // คิวของ batcher หลัก (PipelineHealth)
+	pipeSubs map[chan PipelineEvent]struct{} // SubscribePipeline
+
+	closers []io.Closer // ไฟล์ของ ExporterFile ปิดหลัง flush ใน Shutdown
+
+	rawTraces otlptrace.Client            // ExportRawSpans (connection เดียวกับ trace exporter หลัก)
+	rawLogs   collogspb.LogsServiceClient // ExportRawLogs (connection เดียวกับ log exporter หลัก)
+
+	callers sync.Map // pc → callerFrame ของ caller ใน log
+
+	gcWatch *gcWatcher // Config.GCPauseOnServerSpans
+
+	tracers sync.Map // tracer name → trace.Tracer (ผูกกับ tp ของ provider นี้ เปลี่ยน provider = cache ใหม่)
+
+	healthMu          sync.Mutex
+	lastExportErr     error
+	lastExportErrAt   time.Time
+	lastInstrumentErr error
+}
+
+func newProvider(cfg Config) *Provider {
+	p := &Provider{
+		cfg:            cfg,
+		counters:       newInstrumentCache[metric.Int64Counter](cfg.InstrumentCacheSize),
+		histograms:     newInstrumentCache[metric.Float64Histogram](cfg.InstrumentCacheSize),
+		gauges:         newInstrumentCache[metric.Float64Gauge](cfg.InstrumentCacheSize),
+		metricDefs:     describedMetricDefs(),
+		cardinality:    newCardinalityGuard(cfg.MetricAttrLimit),
+		experiments:    resolveExperiments(cfg),
+		logLevel:       newLogLevel(cfg),
+		logSampler:     newLogSampler(cfg),
+		hashKeys:       newHashKeys(cfg),
+		spanMetricKeys: newSpanMetricKeys(cfg),
+	}
+	p.otelLevel = p.logLevel
+	if cfg.LogLevel == "" {
+		// ไม่ได้ตั้ง LogLevel เอง: OTEL logs รับ debug เหมือนก่อนมี LogLevel (LogDestinations ตั้งทับได้)
+		p.otelLevel = zapcore.DebugLevel
+	}
+	p.SetDefaultMetricAttrs()
+	return p
+}
+
+// defaultProvider คือ Provider ที่ API ระดับ package ใช้ (ตั้งโดย Init / InitPropagationOnly)
 var (
-	globalCfg         Config
-	globalTP          *sdktrace.TracerProvider
-	globalMP          *sdkmetric.MeterProvider
-	globalLogProvider *sdklog.LoggerProvider
-	globalOtelLogger  otellog.Logger
-	globalLogger      *zap.Logger
-	globalPropagator  propagation.TextMapPropagator
-	globalMeter       metric.Meter
+	defaultProvider atomic.Pointer[Provider]
+	noopProvider    = newProvider(Config{})
 )
 
+// std คืน default provider (ถ้ายังไม่ Init คืน provider เปล่าที่ทุกอย่างเป็น no-op)
+func std() *Provider {
+	if p := defaultProvider.Load(); p != nil {
+		return p
+	}
+	return noopProvider
+}
+
+// Init สร้าง Provider ตาม cfg ตั้งเป็น default ของ package และ set OTel globals
+// (TracerProvider / MeterProvider / LoggerProvider / TextMapPropagator)
 func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
-	globalCfg = cfg
+	p, err := New(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := resource.New(
-		ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(cfg.ServiceName),
-			semconv.DeploymentEnvironment(cfg.Environment),
-		),
+	if p.tp != nil {
+		otel.SetTracerProvider(p.tp)
+	}
+	if p.mp != nil {
+		otel.SetMeterProvider(p.mp)
+	}
+	if p.lp != nil {
+		logglobal.SetLoggerProvider(p.lp)
+	}
+	otel.SetTextMapPropagator(p.propagator)
+
+	// export error จาก SDK → เก็บไว้ให้ Health() รายงาน
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		p.setExportErr(err)
+		if p.logger != nil {
+			p.logger.Warn("otel sdk error", zap.Error(err))
+		}
+	}))
+
+	defaultProvider.Store(p)
+
+	return p.Shutdown, nil
+}
+
+// New สร้าง Provider แยกอิสระโดยไม่แตะ global ใด ๆ (ทั้งของ eto และของ OTel)
+// ใช้แบบ:
+//
+//	p, err := eto.New(ctx, cfg)
+//	defer p.Shutdown(context.Background())
+//	ctx, span := p.Trace().Name("job").FromContext(ctx).Start()
+func New(ctx context.Context, cfg Config) (*Provider, error) {
+	if sdkDisabled() {
+		return newDisabledProvider(cfg)
+	}
+	fromEnv := len(cfg.Propagators) == 0
+	cfg, err := applyOTelEnv(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	p := newProvider(cfg)
+
+	res, err := buildResource(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.tracesEnabled() {
+		if err := p.initTraces(ctx, res); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.EnableMetrics {
+		if err := p.initMetrics(ctx, res); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.logsEnabled() {
+		if err := p.initLogs(ctx, res); err != nil {
+			return nil, err
+		}
+	}
+
+	p.propagator = p.newPropagator()
+
+	logger, err := p.newZapLogger()
+	if err != nil {
+		return nil, err
+	}
+	p.logger = logger
+	p.initialized = true
+
+	if fromEnv {
+		if _, unknown := propagatorsFromEnv(); len(unknown) > 0 {
+			logger.Warn("eto: ignoring unsupported propagators in "+envPropagators, zap.Strings("propagators", unknown))
+		}
+	}
+
+	if exps := p.enabledExperiments(); len(exps) > 0 {
+		registerInstrumentation("otelgo", distroModule, map[string]any{"experiments": exps})
+	}
+
+	return p, nil
+}
+
+// newZapLogger สร้าง zap logger ตาม LogDestinations / LogEncoding / LogOutput
+func (p *Provider) newZapLogger() (*zap.Logger, error) {
+	var (
+		logger *zap.Logger
+		err    error
 	)
+	if len(p.cfg.LogDestinations) > 0 {
+		logger, err = p.newDestinationLogger()
+	} else {
+		zcfg := zap.NewProductionConfig()
+		zcfg.Level = p.logLevel
+		if p.logSampler != nil {
+			// sample ใน Send แล้ว ไม่ให้ sampler ของ zap ทิ้งซ้ำอีกชั้น
+			zcfg.Sampling = nil
+		}
+		if p.cfg.logEncoding() == LogEncodingConsole {
+			zcfg.Encoding = LogEncodingConsole
+			zcfg.EncoderConfig = developmentEncoderConfig()
+		}
+		logger, err = zcfg.Build()
+	}
 	if err != nil {
 		return nil, err
 	}
+	return p.withLogOutput(logger)
+}
 
-	traceExp, err := otlpgrpc.New(
-		ctx,
-		otlpgrpc.WithEndpoint(cfg.OtelEndpoint),
+// newDisabledProvider: OTEL_SDK_DISABLED=true ไม่สร้าง trace / metric / log provider และไม่ dial collector
+// Trace / Metric เป็น no-op ส่วน eto.Log() ยังออก zap ตามปกติ
+func newDisabledProvider(cfg Config) (*Provider, error) {
+	p := newProvider(cfg)
+	p.propagator = p.newPropagator()
+	logger, err := p.newZapLogger()
+	if err != nil {
+		return nil, err
+	}
+	p.logger = logger
+	p.initialized = true
+	p.disabled = true
+	return p, nil
+}
+
+// Shutdown flush แล้วปิด provider ทุกตัว พร้อมรายงานจำนวน span / log ที่ส่งไม่ทัน (log + eto_shutdown_pending)
+// metrics ปิดเป็นตัวสุดท้ายเพื่อให้รายงานนี้ออกไปกับรอบ flush สุดท้าย
+func (p *Provider) Shutdown(ctx context.Context) error {
+	start := time.Now()
+	var errs []error
+	var tracesErr, logsErr error
+	if p.tp != nil {
+		tracesErr = p.tp.Shutdown(ctx)
+		errs = append(errs, tracesErr)
+	}
+	if p.lp != nil {
+		logsErr = p.lp.Shutdown(ctx)
+		errs = append(errs, logsErr)
+	}
+	if p.tp != nil || p.lp != nil {
+		p.reportShutdown(tracesErr, logsErr, time.Since(start))
+	}
+	if p.mp != nil {
+		if err := p.mp.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+			if p.logger != nil {
+				p.logger.Warn("eto: metrics flush failed on shutdown", zap.Error(err))
+			}
+		}
+	}
+	for _, c := range p.closers {
+		errs = append(errs, c.Close())
+	}
+	if p.logger != nil {
+		_ = p.logger.Sync()
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Provider) initTraces(ctx context.Context, res *resource.Resource) error {
+	cfg := p.cfg
+	// สร้าง client เองเพื่อให้ ExportRawSpans ใช้ connection + retry ชุดเดียวกับ exporter
+	traceClient := otlpgrpc.NewClient(
+		otlpgrpc.WithEndpoint(cfg.tracesEndpoint()),
 		otlpgrpc.WithInsecure(),
-		otlpgrpc.WithDialOption(grpc.WithBlock()),
+		otlpgrpc.WithHeaders(cfg.OTLPHeaders),
+		otlpgrpc.WithDialOption(cfg.grpcDialOptions()...),
 	)
+	traceExp, err := otlptrace.New(ctx, traceClient)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("%w: trace exporter: %w", ErrExporterDown, err)
+	}
+	p.rawTraces = traceClient
+
+	spanProcessor, err := p.newTenantSpanProcessor(ctx, newMinDurationProcessor(
+		countingSpanProcessor{
+			SpanProcessor: p.newLaneProcessor(countingSpanExporter{SpanExporter: traceExp, c: &p.spanLoss}, cfg.PrioritySpans),
+			c:             &p.spanLoss,
+		},
+		cfg.MinSpanDuration,
+	))
+	if err != nil {
+		return err
 	}
 
-	globalTP = sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(traceExp),
+	tpOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSpanProcessor(spanProcessor),
 		sdktrace.WithResource(res),
-	)
-	otel.SetTracerProvider(globalTP)
+	}
+	if cfg.SamplerHook != nil {
+		tpOpts = append(tpOpts, sdktrace.WithSampler(newHookSampler(cfg.SamplerHook, cfg.Sampler)))
+	} else if cfg.Sampler != nil {
+		tpOpts = append(tpOpts, sdktrace.WithSampler(cfg.Sampler))
+	}
+	if cfg.IDGenerator != nil {
+		tpOpts = append(tpOpts, sdktrace.WithIDGenerator(cfg.IDGenerator))
+	}
+	extra, err := p.extraSpanProcessors(ctx)
+	if err != nil {
+		return err
+	}
+	for _, sp := range extra {
+		tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(sp))
+	}
+	for _, sp := range cfg.SpanProcessors {
+		if sp != nil {
+			tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(sp))
+		}
+	}
+	if cfg.DebugSpanLeaks {
+		tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(newLeakDetector(p)))
+	}
+	if cfg.GCPauseOnServerSpans {
+		p.gcWatch = newGCWatcher()
+		p.closers = append(p.closers, p.gcWatch)
+	}
 
-	if cfg.EnableMetrics {
+	p.tp = sdktrace.NewTracerProvider(tpOpts...)
+	return nil
+}
+
+func (p *Provider) initMetrics(ctx context.Context, res *resource.Resource) error {
+	cfg := p.cfg
+	mpOpts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	if views := metricViews(cfg); len(views) > 0 {
+		mpOpts = append(mpOpts, sdkmetric.WithView(views...))
+	}
+
+	if cfg.metricsPush() {
 		metricExp, err := otlpmetricgrpc.New(
 			ctx,
-			otlpmetricgrpc.WithEndpoint(cfg.OtelEndpoint),
+			otlpmetricgrpc.WithEndpoint(cfg.metricsEndpoint()),
 			otlpmetricgrpc.WithInsecure(),
-			otlpmetricgrpc.WithDialOption(grpc.WithBlock()),
+			otlpmetricgrpc.WithHeaders(cfg.OTLPHeaders),
+			otlpmetricgrpc.WithTemporalitySelector(metricTemporality(cfg)),
+			otlpmetricgrpc.WithDialOption(cfg.grpcDialOptions()...),
 		)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("%w: metric exporter: %w", ErrExporterDown, err)
 		}
+		mpOpts = append(mpOpts, sdkmetric.WithReader(newPeriodicReader(cfg, metricExp)))
+	}
 
-		reader := sdkmetric.NewPeriodicReader(metricExp)
-		globalMP = sdkmetric.NewMeterProvider(
-			sdkmetric.WithReader(reader),
-			sdkmetric.WithResource(res),
-		)
-		otel.SetMeterProvider(globalMP)
-		globalMeter = globalMP.Meter("eto")
+	if cfg.metricsPull() {
+		reader, handler, err := newPrometheusReader()
+		if err != nil {
+			return err
+		}
+		mpOpts = append(mpOpts, sdkmetric.WithReader(reader))
+		p.metricsHandler = handler
 	}
 
+	readers, err := p.extraMetricReaders(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range readers {
+		mpOpts = append(mpOpts, sdkmetric.WithReader(r))
+	}
+
+	p.mp = sdkmetric.NewMeterProvider(mpOpts...)
+	p.meter = p.mp.Meter("eto")
+	return nil
+}
+
+func (p *Provider) initLogs(ctx context.Context, res *resource.Resource) error {
+	cfg := p.cfg
+	// exporter ของ otlploggrpc ไม่เปิด client ให้ใช้ → dial connection เองแล้วใช้ร่วมกับ ExportRawLogs
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, cfg.grpcDialOptions()...)
+	conn, err := grpc.NewClient(cfg.logsEndpoint(), dialOpts...)
+	if err != nil {
+		return fmt.Errorf("%w: log exporter: %w", ErrExporterDown, err)
+	}
+	p.closers = append(p.closers, conn)
 	logExp, err := otlploggrpc.New(
 		ctx,
-		otlploggrpc.WithEndpoint(cfg.OtelEndpoint),
-		otlploggrpc.WithInsecure(),
-		otlploggrpc.WithDialOption(grpc.WithBlock()),
+		otlploggrpc.WithGRPCConn(conn),
+		otlploggrpc.WithHeaders(cfg.OTLPHeaders),
 	)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("%w: log exporter: %w", ErrExporterDown, err)
+	}
+	p.rawLogs = collogspb.NewLogsServiceClient(conn)
+
+	logProcessor, err := p.newTenantLogProcessor(ctx, countingLogProcessor{
+		Processor: p.newGuardedLogBatcher(countingLogExporter{Exporter: logExp, c: &p.logLoss}),
+		c:         &p.logLoss,
+	})
+	if err != nil {
+		return err
 	}
 
-	globalLogProvider = sdklog.NewLoggerProvider(
-		sdklog.WithProcessor(sdklog.NewBatchProcessor(logExp)),
+	lpOpts := []sdklog.LoggerProviderOption{
+		sdklog.WithProcessor(logProcessor),
 		sdklog.WithResource(res),
-	)
-	logglobal.SetLoggerProvider(globalLogProvider)
+	}
+	extra, err := p.extraLogProcessors(ctx)
+	if err != nil {
+		return err
+	}
+	for _, lp := range extra {
+		lpOpts = append(lpOpts, sdklog.WithProcessor(lp))
+	}
 
-	globalOtelLogger = globalLogProvider.Logger("eto")
+	p.lp = sdklog.NewLoggerProvider(lpOpts...)
+	p.otelLogger = p.lp.Logger("eto")
+	return nil
+}
 
-	propagator := propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	)
-	otel.SetTextMapPropagator(propagator)
-	globalPropagator = propagator
+// tracer คืน tracer ของ provider นี้ (ไม่มี TracerProvider → ใช้ของ OTel global ซึ่งเป็น no-op ถ้าไม่ได้ตั้ง)
+// cache ไว้ต่อชื่อเพราะ TracerProvider.Tracer ต้อง lock ทุกครั้ง ซึ่งหนักใน hot path อย่าง middleware
+func (p *Provider) tracer(name string) trace.Tracer {
+	if p.tp == nil {
+		// global ของ OTel เป็น delegate อยู่แล้ว ถ้ามีคน SetTracerProvider ทีหลังก็ตามไปเอง
+		return otel.Tracer(name)
+	}
+	if t, ok := p.tracers.Load(name); ok {
+		return t.(trace.Tracer)
+	}
+	t, _ := p.tracers.LoadOrStore(name, p.tp.Tracer(name))
+	return t.(trace.Tracer)
+}
 
-	logger, err := zap.NewProduction()
-	if err != nil {
-		return nil, err
+// InitPropagationOnly: ตั้งแค่ propagator (ไม่ dial exporter ใด ๆ) สำหรับ gateway ที่แค่ต้อง forward header
+// หลังเรียกแล้ว eto.Propagate() ใช้ได้ปกติ ส่วน Trace / Log / Metric เป็น no-op
+// ใช้ได้เฉพาะ field ที่เกี่ยวกับ propagation ของ cfg (เช่น ExtractLegacyHeaders)
+func InitPropagationOnly(cfg Config) {
+	p := newProvider(cfg)
+	p.propagator = p.newPropagator()
+	otel.SetTextMapPropagator(p.propagator)
+	defaultProvider.Store(p)
+}
+
+// InitNoop: สำหรับ unit test ตั้ง default provider ที่ไม่ต่อ collector และไม่มี output
+// eto.Trace() ยังสร้าง span ที่มี trace id / span id จริง (แต่ไม่ export) ทำให้ Propagate(),
+// trace_id ใน log และ span.SpanContext() ทำงานเหมือนตอนรันจริง ส่วน Log / Metric ไม่ทำอะไร
+// ใช้แบบ:
+//
+//	func TestMain(m *testing.M) {
+//	    shutdown := eto.InitNoop()
+//	    code := m.Run()
+//	    _ = shutdown(context.Background())
+//	    os.Exit(code)
+//	}
+func InitNoop() func(context.Context) error {
+	p := newProvider(Config{ServiceName: "noop"})
+	p.tp = sdktrace.NewTracerProvider() // ไม่มี span processor → ไม่ export
+	p.logger = zap.NewNop()
+	p.propagator = p.newPropagator()
+	p.initialized = true
+
+	otel.SetTracerProvider(p.tp)
+	otel.SetTextMapPropagator(p.propagator)
+	defaultProvider.Store(p)
+
+	return p.Shutdown
+}
+
+func (p *Provider) newPropagator() propagation.TextMapPropagator {
+	cfg := p.cfg
+	var propagators []propagation.TextMapPropagator
+	for _, name := range cfg.propagators() {
+		switch name {
+		case PropagatorTraceContext:
+			propagators = append(propagators, propagation.TraceContext{})
+		case PropagatorBaggage:
+			if cfg.BaggageLimits.enabled() {
+				propagators = append(propagators, limitedBaggage{pv: p, limits: cfg.BaggageLimits})
+				continue
+			}
+			propagators = append(propagators, propagation.Baggage{})
+		case PropagatorXRay:
+			propagators = append(propagators, xrayPropagator{})
+		}
 	}
-	globalLogger = logger
+	if cfg.ExtractLegacyHeaders {
+		propagators = append(propagators, legacyPropagator{})
+	}
+	return propagation.NewCompositeTextMapPropagator(propagators...)
+}
 
-	shutdown := func(ctx context.Context) error {
-		if globalTP != nil {
-			_ = globalTP.Shutdown(ctx)
+func validateConfig(cfg Config) error {
+	if cfg.ServiceName == "" {
+		return fmt.Errorf("%w: ServiceName is required", ErrInvalidConfig)
+	}
+	if cfg.tracesEnabled() && cfg.tracesEndpoint() == "" {
+		return fmt.Errorf("%w: OtelEndpoint or TracesEndpoint is required", ErrInvalidConfig)
+	}
+	switch cfg.MetricsExporter {
+	case "", MetricsExporterOTLP, MetricsExporterPrometheus, MetricsExporterBoth:
+	default:
+		return fmt.Errorf("%w: unknown MetricsExporter %q", ErrInvalidConfig, cfg.MetricsExporter)
+	}
+	if cfg.LogLevel != "" {
+		if _, err := zapcore.ParseLevel(cfg.LogLevel); err != nil {
+			return fmt.Errorf("%w: LogLevel: %w", ErrInvalidConfig, err)
 		}
-		if globalMP != nil {
-			_ = globalMP.Shutdown(ctx)
+	}
+	for _, d := range cfg.LogDestinations {
+		if err := d.validate(); err != nil {
+			return err
 		}
-		if globalLogProvider != nil {
-			_ = globalLogProvider.Shutdown(ctx)
+	}
+	switch cfg.LogEncoding {
+	case "", LogEncodingJSON, LogEncodingConsole:
+	default:
+		return fmt.Errorf("%w: unknown LogEncoding %q", ErrInvalidConfig, cfg.LogEncoding)
+	}
+	for _, name := range cfg.Propagators {
+		switch name {
+		case PropagatorTraceContext, PropagatorBaggage, PropagatorXRay, PropagatorNone:
+		default:
+			return fmt.Errorf("%w: unknown propagator %q", ErrInvalidConfig, name)
 		}
-		if globalLogger != nil {
-			_ = globalLogger.Sync()
+	}
+	if l := cfg.BaggageLimits; l.MaxBytes < 0 || l.MaxMembers < 0 || l.MaxMemberBytes < 0 {
+		return fmt.Errorf("%w: BaggageLimits must be >= 0", ErrInvalidConfig)
+	}
+	if cfg.MaxSpansPerRequest < 0 {
+		return fmt.Errorf("%w: MaxSpansPerRequest must be >= 0", ErrInvalidConfig)
+	}
+	if cfg.DialTimeout < 0 {
+		return fmt.Errorf("%w: DialTimeout must be >= 0", ErrInvalidConfig)
+	}
+	for lvl, s := range cfg.LogSampling {
+		if err := s.validate(lvl); err != nil {
+			return err
 		}
-		return nil
 	}
-
-	return shutdown, nil
+	if lvl := cfg.LogOutput.Level; lvl != "" {
+		if _, err := zapcore.ParseLevel(lvl); err != nil {
+			return fmt.Errorf("%w: LogOutput: %w", ErrInvalidConfig, err)
+		}
+	}
+	switch cfg.MetricTemporality {
+	case "", MetricTemporalityCumulative, MetricTemporalityDelta:
+	default:
+		return fmt.Errorf("%w: unknown MetricTemporality %q", ErrInvalidConfig, cfg.MetricTemporality)
+	}
+	if cfg.MetricExportInterval < 0 {
+		return fmt.Errorf("%w: MetricExportInterval must not be negative", ErrInvalidConfig)
+	}
+	for _, spec := range cfg.Exporters {
+		if err := spec.validate(); err != nil {
+			return err
+		}
+	}
+	if cfg.EnableMetrics && cfg.metricsPush() && cfg.metricsEndpoint() == "" {
+		return fmt.Errorf("%w: OtelEndpoint or MetricsEndpoint is required", ErrInvalidConfig)
+	}
+	if cfg.logsEnabled() && cfg.logsEndpoint() == "" {
+		return fmt.Errorf("%w: OtelEndpoint or LogsEndpoint is required", ErrInvalidConfig)
+	}
+	return nil
 }
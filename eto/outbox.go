@@ -0,0 +1,44 @@
+package eto
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OutboxPublish: ใช้ใน outbox relay ตอนหยิบ row ไป publish
+// traceContext คือค่าที่เก็บไว้ตอน insert row ด้วย eto.Propagate().FromContext(ctx).ToString()
+// span "outbox.publish" จะเป็นลูกของ trace ต้นทาง (request ที่สร้าง row) และ link กลับไปที่ span ของ relay
+// ctx ที่ส่งให้ fn มี span นี้อยู่ ใช้ต่อกับ ToAMQP / ToHTTPHeader ได้เลย
+// ใช้แบบ:
+//
+//	err := eto.OutboxPublish(ctx, row.TraceContext, "orders.created", func(ctx context.Context) error {
+//	    headers := amqp.Table{}
+//	    eto.Propagate().FromContext(ctx).ToAMQP(headers)
+//	    return ch.PublishWithContext(ctx, "orders", "created", false, false, amqp.Publishing{Headers: headers, Body: row.Payload})
+//	})
+func OutboxPublish(ctx context.Context, traceContext, destination string, fn func(ctx context.Context) error) error {
+	if fn == nil {
+		return errors.New("eto.OutboxPublish: fn is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	relaySC := trace.SpanContextFromContext(ctx)
+
+	// เอา span ของ relay ออก แล้วใช้ trace ต้นทางจาก row เป็น parent แทน
+	parent := trace.ContextWithSpanContext(ctx, trace.SpanContext{})
+	parent = Propagate().FromContext(parent).FromString(traceContext)
+
+	b := Trace().
+		Name("outbox.publish").
+		FromContext(parent).
+		Kind(trace.SpanKindProducer).
+		Attr("messaging.destination", destination).
+		Attr("outbox.restored", trace.SpanContextFromContext(parent).IsValid()).
+		Link(relaySC)
+
+	return b.Run(fn)
+}
@@ -0,0 +1,53 @@
+package eto
+
+import (
+	"net"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// PeerService หา peer.service ของ host ปลายทางจาก Config.PeerServiceMap ของ default provider
+// (ว่าง = ไม่มี pattern ไหนตรง) InstrumentResty ใช้ให้อัตโนมัติ ส่วน gRPC client ที่ทำ span เองเรียกใช้ได้ตรง ๆ
+//
+//	ctx, span := eto.Trace().Name("grpc users.Get").Kind(trace.SpanKindClient).
+//	    Attr("peer.service", eto.PeerService(conn.Target())).FromContext(ctx).Start()
+func PeerService(host string) string {
+	return std().peerService(host)
+}
+
+// peerService: ชื่อ host ตรงตัวชนะก่อน ไม่งั้นใช้ glob ที่ยาวที่สุดที่ match (ผลคงที่แม้ map ไม่มีลำดับ)
+// รับได้ทั้ง host, host:port และ URL เต็ม
+func (p *Provider) peerService(host string) string {
+	if len(p.cfg.PeerServiceMap) == 0 {
+		return ""
+	}
+	host = hostOnly(host)
+	if host == "" {
+		return ""
+	}
+	if svc, ok := p.cfg.PeerServiceMap[host]; ok {
+		return svc
+	}
+
+	var best, svc string
+	for pattern, name := range p.cfg.PeerServiceMap {
+		if ok, _ := path.Match(strings.ToLower(pattern), host); ok && len(pattern) > len(best) {
+			best, svc = pattern, name
+		}
+	}
+	return svc
+}
+
+func hostOnly(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "://") {
+		if u, err := url.Parse(s); err == nil {
+			s = u.Host
+		}
+	}
+	if h, _, err := net.SplitHostPort(s); err == nil {
+		s = h
+	}
+	return strings.ToLower(strings.Trim(s, "[]"))
+}
@@ -0,0 +1,239 @@
+package eto
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// คิวใช้เกิน pipelineDegradedAt = degraded, กลับมาปกติเมื่อต่ำกว่า pipelineRecoveredAt (กันแจ้งเตือนแกว่ง)
+const (
+	pipelineDegradedAt  = 0.8
+	pipelineRecoveredAt = 0.5
+)
+
+// QueueStatus สถานะคิวของ batcher ของ signal หนึ่ง (pipeline หลัก)
+type QueueStatus struct {
+	Queued      int64   `json:"queued"`      // เข้าคิวแล้วแต่ยัง export ไม่เสร็จ
+	Capacity    int64   `json:"capacity"`    // 0 = signal นี้ไม่ได้เปิด
+	Utilization float64 `json:"utilization"` // 0..1 (คิวที่เต็มที่สุดของ signal)
+	Dropped     int64   `json:"dropped"`     // ถูกทิ้งเพราะคิวเต็ม
+	Degraded    bool    `json:"degraded"`
+}
+
+// PipelineHealthReport ผลของ PipelineHealth()
+type PipelineHealthReport struct {
+	Traces QueueStatus `json:"traces"`
+	Logs   QueueStatus `json:"logs"`
+}
+
+// Degraded = มี signal ใดใช้คิวเกินเกณฑ์อยู่
+func (r PipelineHealthReport) Degraded() bool {
+	return r.Traces.Degraded || r.Logs.Degraded
+}
+
+// PipelineEvent แจ้งเมื่อคิวเข้า / ออกจากสถานะ degraded (ดู SubscribePipeline)
+type PipelineEvent struct {
+	Signal      string  // "traces" / "logs"
+	Queue       string  // "traces", "traces.priority", "logs"
+	Degraded    bool    // true = เพิ่งเกินเกณฑ์, false = กลับมาปกติ
+	Utilization float64 // ณ ตอนที่เปลี่ยนสถานะ
+}
+
+// PipelineHealth คืนการใช้คิวของ traces / logs ให้ app ลด telemetry ที่ไม่จำเป็น
+// (debug log, span ย่อย) เองเมื่อ exporter ส่งไม่ทัน
+//
+//	if eto.PipelineHealth().Degraded() {
+//		return // ข้าม span ละเอียด
+//	}
+func PipelineHealth() PipelineHealthReport {
+	return std().PipelineHealth()
+}
+
+// PipelineHealth ของ Provider ตัวนี้ (ดู eto.PipelineHealth)
+func (p *Provider) PipelineHealth() PipelineHealthReport {
+	p.pipeMu.Lock()
+	queues := p.queues
+	p.pipeMu.Unlock()
+
+	var r PipelineHealthReport
+	for _, g := range queues {
+		st := &r.Logs
+		if g.signal == "traces" {
+			st = &r.Traces
+		}
+		n := g.inflight.Load()
+		st.Queued += n
+		st.Capacity += g.capacity
+		st.Dropped += g.dropped.Load()
+		if u := g.utilization(n); u > st.Utilization {
+			st.Utilization = u
+		}
+		st.Degraded = st.Degraded || g.degraded.Load()
+	}
+	return r
+}
+
+// SubscribePipeline คืน channel ที่ได้ PipelineEvent ทุกครั้งที่คิวเปลี่ยนสถานะ degraded
+// ผู้รับช้าเกิน buffer จะพลาด event (ไม่ block pipeline) เรียก cancel เมื่อเลิกใช้
+func SubscribePipeline() (<-chan PipelineEvent, func()) {
+	return std().SubscribePipeline()
+}
+
+// SubscribePipeline ของ Provider ตัวนี้ (ดู eto.SubscribePipeline)
+func (p *Provider) SubscribePipeline() (<-chan PipelineEvent, func()) {
+	ch := make(chan PipelineEvent, 16)
+	p.pipeMu.Lock()
+	if p.pipeSubs == nil {
+		p.pipeSubs = map[chan PipelineEvent]struct{}{}
+	}
+	p.pipeSubs[ch] = struct{}{}
+	p.pipeMu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			p.pipeMu.Lock()
+			delete(p.pipeSubs, ch)
+			p.pipeMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (p *Provider) publishPipeline(ev PipelineEvent) {
+	p.pipeMu.Lock()
+	defer p.pipeMu.Unlock()
+	for ch := range p.pipeSubs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// queueGuard นับรายการในคิวของ batcher หนึ่งตัว (เข้าคิวแล้วแต่ export ยังไม่เสร็จ)
+// คิวเต็มแล้ว drop เองก่อนถึง batcher เพราะ batcher ของ SDK drop เงียบ ๆ ทำให้นับไม่ได้
+type queueGuard struct {
+	pv       *Provider
+	signal   string
+	name     string
+	capacity int64
+	inflight atomic.Int64
+	dropped  atomic.Int64
+	degraded atomic.Bool
+}
+
+func (p *Provider) newQueueGuard(signal, name string, capacity int) *queueGuard {
+	g := &queueGuard{pv: p, signal: signal, name: name, capacity: int64(capacity)}
+	p.pipeMu.Lock()
+	p.queues = append(p.queues, g)
+	p.pipeMu.Unlock()
+	return g
+}
+
+func (g *queueGuard) utilization(n int64) float64 {
+	if g.capacity <= 0 {
+		return 0
+	}
+	return float64(n) / float64(g.capacity)
+}
+
+func (g *queueGuard) acquire() bool {
+	n := g.inflight.Add(1)
+	ok := n <= g.capacity
+	if !ok {
+		g.inflight.Add(-1)
+		g.dropped.Add(1)
+		n = g.capacity
+	}
+	if u := g.utilization(n); u >= pipelineDegradedAt && g.degraded.CompareAndSwap(false, true) {
+		g.pv.publishPipeline(PipelineEvent{Signal: g.signal, Queue: g.name, Degraded: true, Utilization: u})
+	}
+	return ok
+}
+
+func (g *queueGuard) release(k int) {
+	n := g.inflight.Add(-int64(k))
+	if u := g.utilization(n); u < pipelineRecoveredAt && g.degraded.CompareAndSwap(true, false) {
+		g.pv.publishPipeline(PipelineEvent{Signal: g.signal, Queue: g.name, Degraded: false, Utilization: u})
+	}
+}
+
+// guardedSpanProcessor / guardedSpanExporter ห่อ batcher ของ span หนึ่งคิวกับ exporter ของมัน
+type guardedSpanProcessor struct {
+	sdktrace.SpanProcessor
+	g *queueGuard
+}
+
+func (p guardedSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	// batcher ทิ้ง span ที่ไม่ถูก sample อยู่แล้ว ไม่ต้องนับ
+	if s.SpanContext().IsSampled() && !p.g.acquire() {
+		return
+	}
+	p.SpanProcessor.OnEnd(s)
+}
+
+type guardedSpanExporter struct {
+	sdktrace.SpanExporter
+	g *queueGuard
+}
+
+func (e guardedSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	defer e.g.release(len(spans))
+	return e.SpanExporter.ExportSpans(ctx, spans)
+}
+
+func (p *Provider) newGuardedSpanBatcher(name string, exp sdktrace.SpanExporter, queueSize int, opts ...sdktrace.BatchSpanProcessorOption) sdktrace.SpanProcessor {
+	g := p.newQueueGuard("traces", name, queueSize)
+	opts = append(opts, sdktrace.WithMaxQueueSize(queueSize))
+	return guardedSpanProcessor{
+		SpanProcessor: sdktrace.NewBatchSpanProcessor(guardedSpanExporter{SpanExporter: exp, g: g}, opts...),
+		g:             g,
+	}
+}
+
+type guardedLogProcessor struct {
+	sdklog.Processor
+	g *queueGuard
+}
+
+func (p guardedLogProcessor) OnEmit(ctx context.Context, rec *sdklog.Record) error {
+	if !p.g.acquire() {
+		return nil
+	}
+	return p.Processor.OnEmit(ctx, rec)
+}
+
+type guardedLogExporter struct {
+	sdklog.Exporter
+	g *queueGuard
+}
+
+func (e guardedLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	defer e.g.release(len(records))
+	return e.Exporter.Export(ctx, records)
+}
+
+func (p *Provider) newGuardedLogBatcher(exp sdklog.Exporter) sdklog.Processor {
+	size := envQueueSize("OTEL_BLRP_MAX_QUEUE_SIZE", 2048)
+	g := p.newQueueGuard("logs", "logs", size)
+	return guardedLogProcessor{
+		Processor: sdklog.NewBatchProcessor(guardedLogExporter{Exporter: exp, g: g}, sdklog.WithMaxQueueSize(size)),
+		g:         g,
+	}
+}
+
+// envQueueSize ขนาดคิวตาม env มาตรฐาน (OTEL_BSP_ / OTEL_BLRP_MAX_QUEUE_SIZE) ให้ guard กับ batcher ตรงกัน
+func envQueueSize(name string, def int) int {
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv(name))); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
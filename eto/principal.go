@@ -0,0 +1,138 @@
+package eto
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	baggageEnduserID     = "enduser.id"
+	baggageClaimPrefix   = "enduser.claim."
+	maxPrincipalValueLen = 256
+)
+
+// Principal คือผู้ใช้ที่ผ่าน authentication แล้ว (เช่น ดึงมาจาก JWT middleware)
+type Principal struct {
+	ID     string
+	Claims map[string]string
+}
+
+// principalKey เก็บ principal ที่ WithPrincipal ใส่ใน process นี้ (เชื่อถือได้ ต่างจาก baggage ที่มากับ request)
+type principalKey struct{}
+
+// WithPrincipal: ใส่ principal ลง baggage เพื่อส่งต่อไป service ปลายทาง และ set enduser.id ที่ span ปัจจุบัน
+// span ที่เริ่มจาก ctx นี้ได้ enduser.id ด้วย (ฝั่งปลายทางได้เฉพาะเมื่อเปิด Config.TrustInboundPrincipal)
+// claim ที่ส่งต่อได้ต้องอยู่ใน Config.PrincipalClaims เท่านั้น (กัน PII หลุด) ที่เหลือถูกทิ้ง
+// ใช้แบบ (ใน JWT middleware): ctx = eto.WithPrincipal(ctx, eto.Principal{ID: sub, Claims: map[string]string{"role": role}})
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if p.ID == "" {
+		return ctx
+	}
+
+	bag := baggage.FromContext(ctx)
+	bag = setBaggageMember(bag, baggageEnduserID, p.ID)
+	pv := std()
+	// claim ที่ไม่ได้ใส่มาใน p (แม้ baggage ขาเข้าจะมี) ไม่นับเป็นของ principal นี้
+	local := Principal{ID: truncatePrincipalValue(p.ID)}
+	for _, claim := range pv.cfg.PrincipalClaims {
+		if v, ok := p.Claims[claim]; ok && v != "" {
+			bag = setBaggageMember(bag, baggageClaimPrefix+claim, v)
+			if local.Claims == nil {
+				local.Claims = map[string]string{}
+			}
+			local.Claims[claim] = truncatePrincipalValue(v)
+		}
+	}
+	ctx = baggage.ContextWithBaggage(ctx, bag)
+	ctx = context.WithValue(ctx, principalKey{}, local)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(pv.principalAttrs(ctx)...)
+	return ctx
+}
+
+// PrincipalFromContext อ่าน principal กลับจาก baggage (ใช้ได้ทั้ง service ต้นทางและปลายทาง)
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	return std().principalFromContext(ctx)
+}
+
+func (pv *Provider) principalFromContext(ctx context.Context) (Principal, bool) {
+	if ctx == nil {
+		return Principal{}, false
+	}
+	bag := baggage.FromContext(ctx)
+	id := bag.Member(baggageEnduserID).Value()
+	if id == "" {
+		return Principal{}, false
+	}
+
+	p := Principal{ID: id}
+	for _, m := range bag.Members() {
+		if !strings.HasPrefix(m.Key(), baggageClaimPrefix) {
+			continue
+		}
+		claim := strings.TrimPrefix(m.Key(), baggageClaimPrefix)
+		if !pv.principalClaimAllowed(claim) {
+			continue
+		}
+		if p.Claims == nil {
+			p.Claims = map[string]string{}
+		}
+		p.Claims[claim] = m.Value()
+	}
+	return p, true
+}
+
+// principalAttrs แปลง principal เป็น span attributes (enduser.id + claim ที่อนุญาต)
+// ใช้ principal จาก WithPrincipal ใน process นี้ ส่วน baggage ที่มากับ request ใช้เฉพาะเมื่อ TrustInboundPrincipal
+func (pv *Provider) principalAttrs(ctx context.Context) []attribute.KeyValue {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	if !ok && pv.cfg.TrustInboundPrincipal {
+		p, ok = pv.principalFromContext(ctx)
+	}
+	if !ok {
+		return nil
+	}
+	attrs := make([]attribute.KeyValue, 0, 1+len(p.Claims))
+	attrs = append(attrs, semconv.EnduserID(p.ID))
+	for k, v := range p.Claims {
+		attrs = append(attrs, attribute.String(baggageClaimPrefix+k, v))
+	}
+	return attrs
+}
+
+func (pv *Provider) principalClaimAllowed(claim string) bool {
+	for _, c := range pv.cfg.PrincipalClaims {
+		if c == claim {
+			return true
+		}
+	}
+	return false
+}
+
+func truncatePrincipalValue(val string) string {
+	if len(val) > maxPrincipalValueLen {
+		return val[:maxPrincipalValueLen]
+	}
+	return val
+}
+
+func setBaggageMember(bag baggage.Baggage, key, val string) baggage.Baggage {
+	m, err := baggage.NewMemberRaw(key, truncatePrincipalValue(val))
+	if err != nil {
+		return bag
+	}
+	out, err := bag.SetMember(m)
+	if err != nil {
+		return bag
+	}
+	return out
+}
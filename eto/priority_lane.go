@@ -0,0 +1,92 @@
+package eto
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// sampledForcedKey attribute ที่ hookSampler ใส่ให้ span ที่ SamplerHook คืน SamplingForce
+const sampledForcedKey = attribute.Key("sampling.forced")
+
+// SpanLane ตั้งค่าคิวด่วนของ span (Config.PrioritySpans)
+type SpanLane struct {
+	QueueSize    int           // 0 = 512
+	MaxBatchSize int           // 0 = 128
+	BatchTimeout time.Duration // 0 = 200ms
+}
+
+// laneProcessor แยก span ที่ error หรือถูก force-sample ไปคิวเล็กที่ flush เร็ว
+// ส่วนที่เหลือไปคิวหลัก (bulk) ทั้งสองคิวส่งผ่าน exporter ตัวเดียวกัน
+type laneProcessor struct {
+	bulk sdktrace.SpanProcessor
+	fast sdktrace.SpanProcessor
+}
+
+func (p *Provider) newLaneProcessor(exp sdktrace.SpanExporter, lane *SpanLane) sdktrace.SpanProcessor {
+	bulk := p.newGuardedSpanBatcher("traces", exp, envQueueSize("OTEL_BSP_MAX_QUEUE_SIZE", sdktrace.DefaultMaxQueueSize))
+	if lane == nil {
+		return bulk
+	}
+	fast := p.newGuardedSpanBatcher("traces.priority", nonClosingSpanExporter{exp}, positiveOr(lane.QueueSize, 512),
+		sdktrace.WithMaxExportBatchSize(positiveOr(lane.MaxBatchSize, 128)),
+		sdktrace.WithBatchTimeout(positiveDurationOr(lane.BatchTimeout, 200*time.Millisecond)),
+	)
+	return &laneProcessor{bulk: bulk, fast: fast}
+}
+
+func (p *laneProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {}
+
+func (p *laneProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if priorityLane(s) {
+		p.fast.OnEnd(s)
+		return
+	}
+	p.bulk.OnEnd(s)
+}
+
+func priorityLane(s sdktrace.ReadOnlySpan) bool {
+	if s.Status().Code == codes.Error {
+		return true
+	}
+	for _, kv := range s.Attributes() {
+		if kv.Key == sampledForcedKey {
+			return kv.Value.AsBool()
+		}
+	}
+	return false
+}
+
+// Shutdown ปิดคิวด่วนก่อน เพราะคิวหลักเป็นคนปิด exporter
+func (p *laneProcessor) Shutdown(ctx context.Context) error {
+	return errors.Join(p.fast.Shutdown(ctx), p.bulk.Shutdown(ctx))
+}
+
+func (p *laneProcessor) ForceFlush(ctx context.Context) error {
+	return errors.Join(p.fast.ForceFlush(ctx), p.bulk.ForceFlush(ctx))
+}
+
+// nonClosingSpanExporter กัน exporter ที่ใช้ร่วมกันถูกปิดซ้ำจากคิวที่สอง
+type nonClosingSpanExporter struct {
+	sdktrace.SpanExporter
+}
+
+func (nonClosingSpanExporter) Shutdown(context.Context) error { return nil }
+
+func positiveOr(v, def int) int {
+	if v > 0 {
+		return v
+	}
+	return def
+}
+
+func positiveDurationOr(v, def time.Duration) time.Duration {
+	if v > 0 {
+		return v
+	}
+	return def
+}
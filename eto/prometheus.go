@@ -0,0 +1,40 @@
+package eto
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// newPrometheusReader สร้าง reader แบบ pull พร้อม registry แยกของ provider นี้
+// (ไม่ใช้ prometheus.DefaultRegisterer เพื่อให้ eto.New หลายตัวใน process เดียวไม่ชนกัน)
+func newPrometheusReader() (sdkmetric.Reader, http.Handler, error) {
+	reg := prometheus.NewRegistry()
+	reader, err := otelprom.New(otelprom.WithRegisterer(reg))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: prometheus exporter: %w", ErrExporterDown, err)
+	}
+	return reader, promhttp.HandlerFor(reg, promhttp.HandlerOpts{}), nil
+}
+
+// MetricsHandler: endpoint ให้ Prometheus scrape (ใช้กับ Config.MetricsExporter = prometheus / both)
+// ผูก route ได้ก่อน Init เพราะ handler จะหา provider ตอนมี request
+// ถ้า provider ไม่ได้เปิดโหมด prometheus จะตอบ 404
+// ใช้แบบ: mux.Handle("/metrics", eto.MetricsHandler())
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		std().MetricsHandler().ServeHTTP(w, r)
+	})
+}
+
+// MetricsHandler ของ Provider ตัวนี้ (ดู eto.MetricsHandler)
+func (p *Provider) MetricsHandler() http.Handler {
+	if p.metricsHandler == nil {
+		return http.NotFoundHandler()
+	}
+	return p.metricsHandler
+}
@@ -2,23 +2,40 @@ package eto
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"strings"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
 )
 
 type PropagationBuilder struct {
-	ctx       context.Context
-	useLegacy bool
-	err       interface{}
+	pv     *Provider
+	ctx    context.Context
+	format HeaderFormat
+	strict bool
+	err    error
 }
 
+var errPropagatorNotReady = fmt.Errorf("eto.Propagate: %w", ErrNotInitialized)
+
 // Propagate เริ่ม Fluent builder สำหรับ Inject/Extract
 func Propagate() *PropagationBuilder {
+	return std().Propagate()
+}
+
+// Propagate สร้าง propagation builder ที่ใช้ propagator ของ Provider ตัวนี้
+func (pv *Provider) Propagate() *PropagationBuilder {
 	return &PropagationBuilder{
+		pv:  pv,
 		ctx: context.Background(),
 	}
 }
@@ -30,58 +47,277 @@ func (p *PropagationBuilder) FromContext(ctx context.Context) *PropagationBuilde
 	return p
 }
 
+// HeaderFormat เลือกว่าจะเขียน header แบบไหนตอน inject
+type HeaderFormat int
+
+const (
+	// headerFormatDefault: ใช้ค่าเริ่มต้นของแต่ละ method
+	// (ToHTTPResponse → legacy, ที่เหลือ → W3C)
+	headerFormatDefault HeaderFormat = iota
+	// HeaderFormatW3C: traceparent / tracestate / baggage ตาม propagator
+	HeaderFormatW3C
+	// HeaderFormatLegacy: x-trace-id / x-span-id
+	HeaderFormatLegacy
+	// HeaderFormatBoth: ทั้ง W3C และ legacy
+	HeaderFormatBoth
+)
+
+// WithLegacyHeaders(true) = HeaderFormats(HeaderFormatBoth) คงไว้เพื่อ backward compatibility
 func (p *PropagationBuilder) WithLegacyHeaders(enable bool) *PropagationBuilder {
-	p.useLegacy = enable
+	if enable {
+		p.format = HeaderFormatBoth
+	} else {
+		p.format = headerFormatDefault
+	}
 	return p
 }
 
-// ---------- HTTP Inbound ----------
+// HeaderFormats กำหนดรูปแบบ header ที่จะเขียน ใช้ได้กับทุก method ฝั่ง inject (รวม ToHTTPResponse)
+func (p *PropagationBuilder) HeaderFormats(f HeaderFormat) *PropagationBuilder {
+	p.format = f
+	return p
+}
 
-func (p *PropagationBuilder) FromHTTPRequest(r *http.Request) context.Context {
-	if globalPropagator == nil {
-		return r.Context()
+func (p *PropagationBuilder) formatOr(def HeaderFormat) HeaderFormat {
+	if p.format == headerFormatDefault {
+		return def
 	}
-	return globalPropagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	return p.format
 }
 
-// ---------- HTTP Outbound ----------
+// Strict: เปิด validation (header เสีย, argument เป็น nil) และ log ทุก error ที่เจอ
+// แทนที่จะเงียบแล้วทำ trace หลุด ใช้คู่กับ Err() เพื่อตรวจผลหลัง Inject/Extract
+func (p *PropagationBuilder) Strict(enable bool) *PropagationBuilder {
+	p.strict = enable
+	return p
+}
 
-func (p *PropagationBuilder) ToHTTPRequest(r *http.Request) {
-	if globalPropagator == nil {
+// Err คืน error แรกที่ builder เจอ (เช่น ยังไม่ได้เรียก Init) ถ้าไม่มีปัญหาจะได้ nil
+func (p *PropagationBuilder) Err() error {
+	return p.err
+}
+
+func (p *PropagationBuilder) setErr(err error) {
+	if err == nil {
+		return
+	}
+	if p.err == nil {
+		p.err = err
+	}
+	if !p.strict {
+		return
+	}
+	if p.pv.logger != nil {
+		p.pv.logger.Warn("eto propagation error", zap.Error(err))
 		return
 	}
-	globalPropagator.Inject(p.ctx, propagation.HeaderCarrier(r.Header))
+	log.Printf("eto propagation error: %v", err)
+}
 
-	if !p.useLegacy {
+// ready เช็คว่า propagator พร้อมใช้ ถ้าไม่พร้อมจะเก็บ error ไว้ให้ Err()
+func (p *PropagationBuilder) ready() bool {
+	if p.pv.propagator == nil {
+		p.setErr(errPropagatorNotReady)
+		return false
+	}
+	return true
+}
+
+// validateExtract: strict mode เท่านั้น → มี traceparent แต่ extract ไม่ได้ span context = header เสีย
+func (p *PropagationBuilder) validateExtract(carrier propagation.TextMapCarrier, ctx context.Context) {
+	if !p.strict {
+		return
+	}
+	tp := carrier.Get("traceparent")
+	if tp == "" {
 		return
 	}
+	if !trace.SpanContextFromContext(ctx).IsRemote() {
+		p.setErr(fmt.Errorf("eto.Propagate: invalid traceparent header %q", tp))
+	}
+}
+
+// ---------- Generic carrier ----------
+
+// Extract: ดึง trace context จาก carrier อะไรก็ได้ที่ implement propagation.TextMapCarrier
+// ใช้กับ transport ที่ builder ยังไม่มี method ให้ เช่น ctx := eto.Propagate().Extract(ctx, myCarrier)
+func (p *PropagationBuilder) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	if ctx == nil {
+		ctx = p.ctx
+	}
+	if carrier == nil {
+		p.setErr(errors.New("eto.Propagate().Extract: carrier is nil"))
+		return ctx
+	}
+	if !p.ready() {
+		return ctx
+	}
+	out := p.pv.propagator.Extract(ctx, carrier)
+	p.validateExtract(carrier, out)
+	return out
+}
 
-	span := trace.SpanFromContext(p.ctx)
-	if span == nil {
+// Inject: ใส่ trace context ลง carrier อะไรก็ได้ (ค่าเริ่มต้น W3C, ปรับด้วย HeaderFormats / WithLegacyHeaders)
+func (p *PropagationBuilder) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	if ctx == nil {
+		ctx = p.ctx
+	}
+	if carrier == nil {
+		p.setErr(errors.New("eto.Propagate().Inject: carrier is nil"))
 		return
 	}
-	sc := span.SpanContext()
+	p.inject(ctx, carrier, p.formatOr(HeaderFormatW3C))
+}
+
+func (p *PropagationBuilder) inject(ctx context.Context, carrier propagation.TextMapCarrier, format HeaderFormat) {
+	if format == HeaderFormatW3C || format == HeaderFormatBoth {
+		if !p.ready() {
+			return
+		}
+		p.pv.propagator.Inject(ctx, carrier)
+	}
+
+	if format != HeaderFormatLegacy && format != HeaderFormatBoth {
+		return
+	}
+
+	sc := trace.SpanContextFromContext(ctx)
 	if !sc.IsValid() {
 		return
 	}
 
-	r.Header.Set("x-trace-id", sc.TraceID().String())
-	r.Header.Set("x-span-id", sc.SpanID().String())
+	carrier.Set("x-trace-id", sc.TraceID().String())
+	carrier.Set("x-span-id", sc.SpanID().String())
 }
 
-// ---------- HTTP Response ----------
+// ---------- String (persist ลง DB / job payload) ----------
 
-func (p *PropagationBuilder) ToHTTPResponse(w http.ResponseWriter) {
-	span := trace.SpanFromContext(p.ctx)
-	if span == nil {
+// ToString: serialize trace context (traceparent / tracestate / baggage) เป็น string สั้น ๆ
+// เก็บลง DB row หรือ payload ได้ แล้วค่อย FromString ตอน worker หยิบไปทำ
+func (p *PropagationBuilder) ToString() string {
+	carrier := propagation.MapCarrier{}
+	p.Inject(p.ctx, carrier)
+	if len(carrier) == 0 {
+		return ""
+	}
+	vals := url.Values{}
+	for k, v := range carrier {
+		vals.Set(k, v)
+	}
+	return vals.Encode()
+}
+
+// FromString: อ่าน string จาก ToString กลับเป็น context (ฐานคือ ctx จาก FromContext)
+func (p *PropagationBuilder) FromString(s string) context.Context {
+	if s == "" {
+		return p.ctx
+	}
+	vals, err := url.ParseQuery(s)
+	if err != nil {
+		p.setErr(fmt.Errorf("eto.Propagate().FromString: %w", err))
+		return p.ctx
+	}
+	carrier := propagation.MapCarrier{}
+	for k := range vals {
+		carrier.Set(k, vals.Get(k))
+	}
+	return p.Extract(p.ctx, carrier)
+}
+
+// ContextWithRemoteTrace: ใส่ trace ต้นทางที่มีแค่ id (webhook ของ vendor, id ที่ดึงจาก log) เป็น remote parent
+// span ที่สร้างจาก ctx ที่คืนจะอยู่ใน trace เดียวกัน รับ hex ตัวเล็ก / ใหญ่ และ trace id แบบ UUID (มีขีด)
+// spanID ว่างได้ = สุ่มให้ (span ต้นทางจะไม่มีใน backend แต่ trace ยังต่อกัน)
+//
+//	ctx, err := eto.ContextWithRemoteTrace(ctx, payload.TraceID, payload.SpanID, true)
+//	ctx, span := eto.Trace().Name("vendor.webhook").FromContext(ctx).Start()
+func ContextWithRemoteTrace(ctx context.Context, traceID, spanID string, sampled bool) (context.Context, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tid, err := trace.TraceIDFromHex(normalizeHexID(traceID))
+	if err != nil {
+		return ctx, fmt.Errorf("eto.ContextWithRemoteTrace: trace id %q: %w", traceID, err)
+	}
+
+	var sid trace.SpanID
+	if strings.TrimSpace(spanID) == "" {
+		_, _ = rand.Read(sid[:])
+	} else if sid, err = trace.SpanIDFromHex(normalizeHexID(spanID)); err != nil {
+		return ctx, fmt.Errorf("eto.ContextWithRemoteTrace: span id %q: %w", spanID, err)
+	}
+
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: flags,
+		Remote:     true,
+	})
+	return trace.ContextWithRemoteSpanContext(ctx, sc), nil
+}
+
+func normalizeHexID(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "-", ""))
+}
+
+// ---------- HTTP Inbound ----------
+
+func (p *PropagationBuilder) FromHTTPRequest(r *http.Request) context.Context {
+	if r == nil {
+		p.setErr(errors.New("eto.Propagate().FromHTTPRequest: request is nil"))
+		return p.ctx
+	}
+	if !p.ready() {
+		return r.Context()
+	}
+	carrier := propagation.HeaderCarrier(r.Header)
+	ctx := p.pv.propagator.Extract(r.Context(), carrier)
+	p.validateExtract(carrier, ctx)
+	return ctx
+}
+
+// FromHTTPHeader: ดึง trace context จาก http.Header โดยใช้ ctx จาก FromContext เป็นฐาน
+// ใช้แบบ: ctx := eto.Propagate().FromContext(ctx).FromHTTPHeader(h)
+func (p *PropagationBuilder) FromHTTPHeader(h http.Header) context.Context {
+	if h == nil {
+		p.setErr(errors.New("eto.Propagate().FromHTTPHeader: header is nil"))
+		return p.ctx
+	}
+	return p.Extract(p.ctx, propagation.HeaderCarrier(h))
+}
+
+// ---------- HTTP Outbound ----------
+
+func (p *PropagationBuilder) ToHTTPRequest(r *http.Request) {
+	if r == nil {
+		p.setErr(errors.New("eto.Propagate().ToHTTPRequest: request is nil"))
 		return
 	}
-	sc := span.SpanContext()
-	if !sc.IsValid() {
+	p.ToHTTPHeader(r.Header)
+}
+
+// ToHTTPHeader: inject trace context ลง http.Header ตรง ๆ (รองรับ WithLegacyHeaders)
+// ใช้ตอนประกอบ request ทีละส่วน หรือ framework ที่ให้มาแค่ header
+func (p *PropagationBuilder) ToHTTPHeader(h http.Header) {
+	if h == nil {
+		p.setErr(errors.New("eto.Propagate().ToHTTPHeader: header is nil"))
 		return
 	}
-	w.Header().Set("x-trace-id", sc.TraceID().String())
-	w.Header().Set("x-span-id", sc.SpanID().String())
+	p.Inject(p.ctx, propagation.HeaderCarrier(h))
+}
+
+// ---------- HTTP Response ----------
+
+// ToHTTPResponse: ค่าเริ่มต้นเขียน x-trace-id / x-span-id ให้ client (เปลี่ยนได้ด้วย HeaderFormats)
+func (p *PropagationBuilder) ToHTTPResponse(w http.ResponseWriter) {
+	if w == nil {
+		p.setErr(errors.New("eto.Propagate().ToHTTPResponse: response writer is nil"))
+		return
+	}
+	p.inject(p.ctx, propagation.HeaderCarrier(w.Header()), p.formatOr(HeaderFormatLegacy))
 }
 
 // ---------- gRPC (optional) ----------
@@ -111,22 +347,24 @@ func (c metadataCarrier) Keys() []string {
 }
 
 func (p *PropagationBuilder) FromGRPCMetadata(ctx context.Context, md metadata.MD) context.Context {
-	if globalPropagator == nil {
+	if !p.ready() {
 		return ctx
 	}
 	carrier := metadataCarrier{md}
-	return globalPropagator.Extract(ctx, carrier)
+	out := p.pv.propagator.Extract(ctx, carrier)
+	p.validateExtract(carrier, out)
+	return out
 }
 
 func (p *PropagationBuilder) ToGRPCMetadata(md *metadata.MD) {
-	if globalPropagator == nil || md == nil {
+	if md == nil {
+		p.setErr(errors.New("eto.Propagate().ToGRPCMetadata: metadata pointer is nil"))
 		return
 	}
 	if *md == nil {
 		*md = metadata.MD{}
 	}
-	carrier := metadataCarrier{*md}
-	globalPropagator.Inject(p.ctx, carrier)
+	p.Inject(p.ctx, metadataCarrier{*md})
 }
 
 // ---------- AMQP (RabbitMQ) ----------
@@ -158,36 +396,26 @@ func (c amqpHeaderCarrier) Keys() []string {
 // FromAMQP: ดึง trace context จาก headers ของ AMQP message
 // ใช้แบบ: ctx := eto.Propagate().FromContext(baseCtx).FromAMQP(msg.Headers)
 func (p *PropagationBuilder) FromAMQP(headers amqp.Table) context.Context {
-	if globalPropagator == nil {
+	if !p.ready() {
 		return p.ctx
 	}
 	carrier := amqpHeaderCarrier(headers)
-	return globalPropagator.Extract(p.ctx, carrier)
+	ctx := p.pv.propagator.Extract(p.ctx, carrier)
+	p.validateExtract(carrier, ctx)
+	return ctx
 }
 
 // ToAMQP: inject trace context ลง headers เวลาจะ publish
+// พร้อมเวลา publish (AMQPPublishedAtHeader) ถ้ายังไม่มี เพื่อให้ consumer วัด lag ได้
+// (re-publish / retry จึงยังวัดจากเวลา publish ครั้งแรก)
 // ใช้แบบ: eto.Propagate().FromContext(ctx).WithLegacyHeaders(true).ToAMQP(headers)
 func (p *PropagationBuilder) ToAMQP(headers amqp.Table) {
-	if globalPropagator == nil {
+	if headers == nil {
+		p.setErr(errors.New("eto.Propagate().ToAMQP: headers table is nil"))
 		return
 	}
-	carrier := amqpHeaderCarrier(headers)
-	globalPropagator.Inject(p.ctx, carrier)
-
-	if !p.useLegacy {
-		return
+	p.Inject(p.ctx, amqpHeaderCarrier(headers))
+	if _, ok := headers[AMQPPublishedAtHeader]; !ok {
+		headers[AMQPPublishedAtHeader] = p.pv.now().UnixMilli()
 	}
-
-	// เพิ่ม x-trace-id / x-span-id เป็น legacy header (optional)
-	span := trace.SpanFromContext(p.ctx)
-	if span == nil {
-		return
-	}
-	sc := span.SpanContext()
-	if !sc.IsValid() {
-		return
-	}
-
-	headers["x-trace-id"] = sc.TraceID().String()
-	headers["x-span-id"] = sc.SpanID().String()
 }
@@ -0,0 +1,67 @@
+package eto
+
+import (
+	"context"
+	"fmt"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// ExportRawSpans (advanced) ส่ง ResourceSpans ที่สร้างเอง (เช่น แปลงมาจาก tracing format เดิมของระบบ)
+// ตรงไปที่ collector ผ่าน connection, header และ retry ชุดเดียวกับ trace exporter หลัก
+// ไม่ผ่าน sampler / span processor / tenant routing และส่งทันทีไม่รอ batch (caller รวม batch มาเอง)
+func ExportRawSpans(ctx context.Context, spans []*tracepb.ResourceSpans) error {
+	return std().ExportRawSpans(ctx, spans)
+}
+
+// ExportRawSpans ของ Provider ตัวนี้ (ดู eto.ExportRawSpans)
+func (p *Provider) ExportRawSpans(ctx context.Context, spans []*tracepb.ResourceSpans) error {
+	if !p.initialized {
+		return ErrNotInitialized
+	}
+	if p.rawTraces == nil {
+		return fmt.Errorf("%w: traces", ErrSignalDisabled)
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+	if err := p.rawTraces.UploadTraces(ctx, spans); err != nil {
+		p.setExportErr(err)
+		return fmt.Errorf("%w: raw spans: %w", ErrExporterDown, err)
+	}
+	return nil
+}
+
+// ExportRawLogs (advanced) ส่ง ResourceLogs ที่สร้างเองผ่าน connection และ header ของ log exporter หลัก
+// เหมือน ExportRawSpans แต่ไม่ retry (client ของ otlploggrpc ไม่เปิดให้ใช้) ให้ caller retry เองเมื่อได้ ErrExporterDown
+func ExportRawLogs(ctx context.Context, logs []*logspb.ResourceLogs) error {
+	return std().ExportRawLogs(ctx, logs)
+}
+
+// ExportRawLogs ของ Provider ตัวนี้ (ดู eto.ExportRawLogs)
+func (p *Provider) ExportRawLogs(ctx context.Context, logs []*logspb.ResourceLogs) error {
+	if !p.initialized {
+		return ErrNotInitialized
+	}
+	if p.rawLogs == nil {
+		return fmt.Errorf("%w: logs", ErrSignalDisabled)
+	}
+	if len(logs) == 0 {
+		return nil
+	}
+	if len(p.cfg.OTLPHeaders) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(p.cfg.OTLPHeaders))
+	}
+	resp, err := p.rawLogs.Export(ctx, &collogspb.ExportLogsServiceRequest{ResourceLogs: logs})
+	if err != nil {
+		p.setExportErr(err)
+		return fmt.Errorf("%w: raw logs: %w", ErrExporterDown, err)
+	}
+	if ps := resp.GetPartialSuccess(); ps.GetRejectedLogRecords() > 0 {
+		return fmt.Errorf("%w: raw logs: %d rejected: %s", ErrExporterDown, ps.GetRejectedLogRecords(), ps.GetErrorMessage())
+	}
+	return nil
+}
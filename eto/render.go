@@ -0,0 +1,96 @@
+package eto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Template คือสิ่งที่ RenderTemplate ใช้ได้ (*text/template.Template และ *html/template.Template)
+type Template interface {
+	Name() string
+	Execute(w io.Writer, data any) error
+}
+
+// RenderJSON encode v เป็น JSON แล้วเขียนลง w ภายใต้ span "render.json"
+// span ได้ render.size_bytes / render.encode_ms (+ error ถ้า encode หรือเขียนไม่ผ่าน)
+// และ histogram render_duration_ms / render_size_bytes {format} เพื่อแยกเวลา serialize ออกจาก handler
+// encode ลง buffer ก่อน ถ้า error จะไม่มีอะไรถูกเขียนลง w ครึ่ง ๆ
+//
+//	if err := eto.RenderJSON(r.Context(), w, resp); err != nil { ... }
+func RenderJSON(ctx context.Context, w io.Writer, v any) error {
+	return std().RenderJSON(ctx, w, v)
+}
+
+// RenderJSON ของ Provider ตัวนี้ (ดู eto.RenderJSON)
+func (p *Provider) RenderJSON(ctx context.Context, w io.Writer, v any) error {
+	return p.render(ctx, "json", "", w, func() ([]byte, error) {
+		return json.Marshal(v)
+	})
+}
+
+// RenderTemplate render tpl ด้วย data แล้วเขียนลง w ภายใต้ span "render.template" (template.name = tpl.Name())
+// วัดผลแบบเดียวกับ RenderJSON
+//
+//	err := eto.RenderTemplate(r.Context(), w, tpl.Lookup("order.html"), page)
+func RenderTemplate(ctx context.Context, w io.Writer, tpl Template, data any) error {
+	return std().RenderTemplate(ctx, w, tpl, data)
+}
+
+// RenderTemplate ของ Provider ตัวนี้ (ดู eto.RenderTemplate)
+func (p *Provider) RenderTemplate(ctx context.Context, w io.Writer, tpl Template, data any) error {
+	if tpl == nil {
+		return errors.New("eto.RenderTemplate: template is nil")
+	}
+	return p.render(ctx, "template", tpl.Name(), w, func() ([]byte, error) {
+		var buf bytes.Buffer
+		err := tpl.Execute(&buf, data)
+		return buf.Bytes(), err
+	})
+}
+
+func (p *Provider) render(ctx context.Context, format, tplName string, w io.Writer, encode func() ([]byte, error)) error {
+	if w == nil {
+		return errors.New("eto.Render: writer is nil")
+	}
+	b := p.Trace().
+		Name("render."+format).
+		FromContext(ctx).
+		Attr("render.format", format)
+	if tplName != "" {
+		b = b.Attr("template.name", tplName)
+	}
+	return b.Run(func(ctx context.Context) error {
+		start := time.Now()
+		out, err := encode()
+		elapsed := time.Since(start)
+
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.Int("render.size_bytes", len(out)),
+			attribute.Float64("render.encode_ms", durationMs(elapsed)),
+		)
+		p.MetricHistogram("render_duration_ms").
+			Unit("ms").
+			Description("time spent encoding / rendering response payloads").
+			Attr("format", format).
+			Record(ctx, durationMs(elapsed))
+		if err != nil {
+			return fmt.Errorf("render %s: %w", format, err)
+		}
+		p.MetricHistogram("render_size_bytes").
+			Unit("By").
+			Description("size of rendered response payloads").
+			Attr("format", format).
+			Record(ctx, float64(len(out)))
+
+		_, err = w.Write(out)
+		return err
+	})
+}
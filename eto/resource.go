@@ -0,0 +1,111 @@
+package eto
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// distroModule ชื่อ module ของ otelgo ใช้หา version จาก build info ไปใส่ telemetry.distro.version
+const distroModule = "github.com/Maximumsoft-Co-LTD/otelgo"
+
+// buildResource รวม resource attributes ที่จะติดไปกับทุก signal (traces / metrics / logs)
+func buildResource(ctx context.Context, cfg Config) (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.DeploymentEnvironmentName(cfg.Environment),
+		// key เดิม (semconv < 1.27) คงไว้ให้ dashboard / query เก่ายังใช้ได้
+		attribute.String("deployment.environment", cfg.Environment),
+		semconv.TelemetryDistroName("otelgo"),
+	}
+	if v := moduleVersion(distroModule); v != "" {
+		attrs = append(attrs, semconv.TelemetryDistroVersion(v))
+	}
+	if cfg.ServiceVersion != "" {
+		attrs = append(attrs, semconv.ServiceVersion(cfg.ServiceVersion))
+	}
+	if cfg.ServiceNamespace != "" {
+		attrs = append(attrs, semconv.ServiceNamespace(cfg.ServiceNamespace))
+	}
+	instanceID := cfg.ServiceInstanceID
+	if instanceID == "" {
+		instanceID = processInstanceID()
+	}
+	attrs = append(attrs, semconv.ServiceInstanceID(instanceID))
+
+	// sort key ให้ลำดับคงที่ (map iteration สุ่ม)
+	keys := make([]string, 0, len(cfg.ResourceAttributes))
+	for k := range cfg.ResourceAttributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attrs = append(attrs, attribute.String(k, cfg.ResourceAttributes[k]))
+	}
+
+	// schema URL ต้องตรงกับ semconv ที่ SDK ใช้ใน detector / telemetry.sdk.* ไม่งั้นจะ conflict แล้วหายไป
+	opts := []resource.Option{
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(attrs...),
+	}
+	if cfg.DetectResources {
+		opts = append(opts,
+			resource.WithHost(),
+			resource.WithOS(),
+			resource.WithProcessPID(),
+			resource.WithProcessExecutableName(),
+			resource.WithProcessRuntimeName(),
+			resource.WithProcessRuntimeVersion(),
+			resource.WithContainer(),
+			resource.WithAttributes(k8sAttrsFromEnv()...),
+		)
+	}
+
+	res, err := resource.New(ctx, opts...)
+	if errors.Is(err, resource.ErrPartialResource) || errors.Is(err, resource.ErrSchemaURLConflict) {
+		// detector บางตัวใช้ไม่ได้บนเครื่องนี้ (เช่น ไม่ได้รันใน container) → ใช้เท่าที่ได้
+		return res, nil
+	}
+	return res, err
+}
+
+// processInstanceID: service.instance.id ค่าเริ่มต้น สุ่มครั้งเดียวแล้วใช้ทั้ง process (ทุก Provider ได้ค่าเดียวกัน)
+var processInstanceID = sync.OnceValue(func() string {
+	return uuid.NewString()
+})
+
+// k8sAttrsFromEnv อ่านข้อมูล pod จาก env ที่ inject ผ่าน downward API
+// เช่น env: - name: K8S_POD_NAME valueFrom: fieldRef: fieldPath: metadata.name
+func k8sAttrsFromEnv() []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if v := firstEnv("K8S_POD_NAME", "POD_NAME"); v != "" {
+		attrs = append(attrs, semconv.K8SPodName(v))
+	}
+	if v := firstEnv("K8S_POD_UID", "POD_UID"); v != "" {
+		attrs = append(attrs, semconv.K8SPodUID(v))
+	}
+	if v := firstEnv("K8S_NAMESPACE_NAME", "POD_NAMESPACE"); v != "" {
+		attrs = append(attrs, semconv.K8SNamespaceName(v))
+	}
+	if v := firstEnv("K8S_NODE_NAME", "NODE_NAME"); v != "" {
+		attrs = append(attrs, semconv.K8SNodeName(v))
+	}
+	return attrs
+}
+
+func firstEnv(keys ...string) string {
+	for _, k := range keys {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
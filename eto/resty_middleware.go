@@ -0,0 +1,170 @@
+package eto
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type restyStateKey struct{}
+
+// restyState เก็บ span ของ request หนึ่งครั้ง (รวมทุก retry) ไว้ใน ctx ของ resty.Request
+type restyState struct {
+	req    *resty.Request
+	span   trace.Span
+	start  time.Time
+	status int
+	once   sync.Once
+}
+
+// InstrumentResty: ผูก hook ให้ resty client สร้าง client span + inject header + นับ metrics
+// retry แต่ละครั้งจะถูกบันทึกเป็น span event "http.retry" บน span เดียวกัน
+// ใช้แบบ: client := eto.InstrumentResty(resty.New())
+func InstrumentResty(client *resty.Client) *resty.Client {
+	if client == nil {
+		return nil
+	}
+	registerInstrumentation("resty", "github.com/go-resty/resty/v2", nil)
+
+	client.OnBeforeRequest(func(c *resty.Client, r *resty.Request) error {
+		if st := restyStateFrom(r.Context()); st != nil && st.req == r {
+			// retry → span เดิม แค่ inject header ใหม่ให้ attempt นี้
+			Propagate().FromContext(r.Context()).ToHTTPHeader(r.Header)
+			return nil
+		}
+
+		methodKey, _, urlKey := std().httpClientKeys()
+		b := Trace().
+			Name("HTTP "+r.Method).
+			FromContext(r.Context()).
+			Kind(trace.SpanKindClient).
+			Attr(methodKey, r.Method).
+			Attr(urlKey, r.URL)
+		// hook ของผู้ใช้รันก่อน resty รวม BaseURL เข้า r.URL จึงต้องดู BaseURL เองถ้า URL ยังเป็น path
+		target := r.URL
+		if !strings.Contains(target, "://") {
+			target = c.BaseURL
+		}
+		if svc := PeerService(target); svc != "" {
+			b.Attr("peer.service", svc)
+		}
+		ctx, span := b.Start()
+
+		st := &restyState{req: r, span: span, start: time.Now()}
+		ctx = context.WithValue(ctx, restyStateKey{}, st)
+		r.SetContext(ctx)
+
+		Propagate().FromContext(ctx).ToHTTPHeader(r.Header)
+		return nil
+	})
+
+	client.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		if st := restyStateFrom(resp.Request.Context()); st != nil {
+			st.status = resp.StatusCode()
+		}
+		return nil
+	})
+
+	client.AddRetryHook(func(resp *resty.Response, err error) {
+		if resp == nil || resp.Request == nil {
+			return
+		}
+		st := restyStateFrom(resp.Request.Context())
+		if st == nil {
+			return
+		}
+
+		_, statusKey, _ := std().httpClientKeys()
+		attrs := []attribute.KeyValue{
+			attribute.Int("http.retry.attempt", resp.Request.Attempt),
+		}
+		if code := resp.StatusCode(); code != 0 {
+			attrs = append(attrs, attribute.Int(statusKey, code))
+		}
+		if err != nil {
+			attrs = append(attrs, attribute.String("error", err.Error()))
+		}
+		st.span.AddEvent("http.retry", trace.WithAttributes(attrs...))
+	})
+
+	client.OnSuccess(func(_ *resty.Client, resp *resty.Response) {
+		finishResty(resp.Request, nil)
+	})
+	client.OnError(func(r *resty.Request, err error) {
+		finishResty(r, err)
+	})
+	client.OnInvalid(func(r *resty.Request, err error) {
+		finishResty(r, err)
+	})
+	client.OnPanic(func(r *resty.Request, err error) {
+		finishResty(r, err)
+	})
+
+	return client
+}
+
+func restyStateFrom(ctx context.Context) *restyState {
+	if ctx == nil {
+		return nil
+	}
+	st, _ := ctx.Value(restyStateKey{}).(*restyState)
+	return st
+}
+
+// finishResty ปิด span + บันทึก metrics ครั้งเดียวหลัง retry ทั้งหมดจบแล้ว
+func finishResty(r *resty.Request, err error) {
+	if r == nil {
+		return
+	}
+	ctx := r.Context()
+	st := restyStateFrom(ctx)
+	if st == nil {
+		return
+	}
+
+	st.once.Do(func() {
+		var respErr *resty.ResponseError
+		if errors.As(err, &respErr) && respErr.Response != nil {
+			st.status = respErr.Response.StatusCode()
+		}
+
+		if st.status != 0 {
+			_, statusKey, _ := std().httpClientKeys()
+			st.span.SetAttributes(attribute.Int(statusKey, st.status))
+		}
+		if r.Attempt > 1 {
+			st.span.SetAttributes(attribute.Int("http.retry_count", r.Attempt-1))
+		}
+
+		outcome := "success"
+		if err != nil || st.status >= 500 {
+			outcome = "error"
+			if err != nil {
+				std().recordError(st.span, err)
+				st.span.SetStatus(codes.Error, std().statusDescription(err))
+			} else {
+				st.span.SetStatus(codes.Error, strconv.Itoa(st.status))
+			}
+		}
+		st.span.End(std().spanEndOptions()...)
+
+		MetricCounter("http_client_requests_total").
+			Attr("method", r.Method).
+			Attr("status_code", strconv.Itoa(st.status)).
+			Attr("status", outcome).
+			Add(ctx, 1)
+
+		MetricHistogram("http_client_request_duration_ms").
+			Attr("method", r.Method).
+			Attr("status", outcome).
+			Record(ctx, float64(time.Since(st.start).Milliseconds()))
+	})
+}
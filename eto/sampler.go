@@ -0,0 +1,59 @@
+package eto
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SamplingDecision ผลจาก SamplerHook
+type SamplingDecision int
+
+const (
+	// SamplingDefault ให้ sampler ปกติ (parent-based) ตัดสินเหมือนเดิม
+	SamplingDefault SamplingDecision = iota
+	// SamplingForce บังคับเก็บ span นี้ (เช่น ลูกค้า premium, beta flag ใน baggage)
+	SamplingForce
+	// SamplingDrop ทิ้ง span นี้
+	SamplingDrop
+)
+
+// SamplerHook ให้ business logic ตัดสินใจ sampling ต่อ span
+// attrs คือ attribute ที่ใส่ผ่าน builder ก่อน Start (เช่น .Attr("customer.tier", "premium"))
+type SamplerHook func(ctx context.Context, spanName string, attrs []attribute.KeyValue) SamplingDecision
+
+type hookSampler struct {
+	hook SamplerHook
+	base sdktrace.Sampler
+}
+
+func newHookSampler(hook SamplerHook, base sdktrace.Sampler) sdktrace.Sampler {
+	if base == nil {
+		base = sdktrace.ParentBased(sdktrace.AlwaysSample())
+	}
+	return &hookSampler{hook: hook, base: base}
+}
+
+func (s *hookSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	switch s.hook(p.ParentContext, p.Name, p.Attributes) {
+	case SamplingForce:
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Attributes: []attribute.KeyValue{sampledForcedKey.Bool(true)},
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	case SamplingDrop:
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.Drop,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	default:
+		return s.base.ShouldSample(p)
+	}
+}
+
+func (s *hookSampler) Description() string {
+	return "EtoHookSampler{" + s.base.Description() + "}"
+}
@@ -0,0 +1,109 @@
+package eto
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+// lossCounter นับรายการที่เข้าคิวของ pipeline หลักเทียบกับที่ export สำเร็จ
+// ส่วนต่างหลัง Shutdown = ค้างในคิว / ถูก drop / export ไม่ผ่าน (หายระหว่าง deploy)
+type lossCounter struct {
+	enqueued atomic.Int64
+	exported atomic.Int64
+}
+
+func (c *lossCounter) pending() int64 {
+	if n := c.enqueued.Load() - c.exported.Load(); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// countingSpanProcessor นับ span ก่อนเข้า batcher
+type countingSpanProcessor struct {
+	sdktrace.SpanProcessor
+	c *lossCounter
+}
+
+func (p countingSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	// span ที่ไม่ถูก sample (record-only) batcher ทิ้งเองอยู่แล้ว ไม่ใช่ของที่หาย
+	if s.SpanContext().IsSampled() {
+		p.c.enqueued.Add(1)
+	}
+	p.SpanProcessor.OnEnd(s)
+}
+
+type countingSpanExporter struct {
+	sdktrace.SpanExporter
+	c *lossCounter
+}
+
+func (e countingSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	if err == nil {
+		e.c.exported.Add(int64(len(spans)))
+	}
+	return err
+}
+
+// countingLogProcessor นับ log record ก่อนเข้า batcher
+type countingLogProcessor struct {
+	sdklog.Processor
+	c *lossCounter
+}
+
+func (p countingLogProcessor) OnEmit(ctx context.Context, rec *sdklog.Record) error {
+	p.c.enqueued.Add(1)
+	return p.Processor.OnEmit(ctx, rec)
+}
+
+type countingLogExporter struct {
+	sdklog.Exporter
+	c *lossCounter
+}
+
+func (e countingLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	err := e.Exporter.Export(ctx, records)
+	if err == nil {
+		e.c.exported.Add(int64(len(records)))
+	}
+	return err
+}
+
+// reportShutdown log สรุปสิ่งที่ยังไม่ถูกส่งตอน Shutdown และส่ง eto_shutdown_pending{signal, flushed}
+// ก่อนปิด MeterProvider (best effort: ถ้า metric pipeline ล่มก็เหลือแค่ log)
+func (p *Provider) reportShutdown(tracesErr, logsErr error, elapsed time.Duration) {
+	spans, logs := p.spanLoss.pending(), p.logLoss.pending()
+
+	if p.meter != nil && p.cfg.EnableMetrics {
+		if g := p.getOrCreateGauge("eto_shutdown_pending", "1", "spans / logs not exported when the provider shut down"); g != nil {
+			g.Record(context.Background(), float64(spans), metric.WithAttributes(
+				attribute.String("signal", "traces"), attribute.Bool("flushed", p.tp != nil && tracesErr == nil)))
+			g.Record(context.Background(), float64(logs), metric.WithAttributes(
+				attribute.String("signal", "logs"), attribute.Bool("flushed", p.lp != nil && logsErr == nil)))
+		}
+	}
+
+	if p.logger == nil {
+		return
+	}
+	fields := []zap.Field{
+		zap.Int64("spans_pending", spans),
+		zap.Int64("logs_pending", logs),
+		zap.Bool("traces_flushed", tracesErr == nil),
+		zap.Bool("logs_flushed", logsErr == nil),
+		zap.Duration("elapsed", elapsed),
+	}
+	if spans > 0 || logs > 0 || tracesErr != nil || logsErr != nil {
+		p.logger.Warn("eto: telemetry lost during shutdown", fields...)
+		return
+	}
+	p.logger.Info("eto: telemetry flushed on shutdown", fields...)
+}
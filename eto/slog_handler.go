@@ -0,0 +1,123 @@
+package eto
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// slogHandler ส่ง record ของ log/slog ผ่าน eto.Log() (OTEL logs + zap พร้อม trace_id / span_id จาก ctx)
+type slogHandler struct {
+	prefix string      // group ที่เปิดอยู่ เช่น "request."
+	fields []zap.Field // attribute จาก WithAttrs ที่ flatten แล้ว
+}
+
+// NewSlogHandler คืน slog.Handler ของ default provider (หา provider ตอนเขียน จึงสร้างก่อน eto.Init ได้)
+// level ตามที่ตั้งใน Config.LogLevel / SetLogLevel, group กลายเป็น prefix ของ key เช่น "request.id"
+// ต้องใช้ *Context (InfoContext, ErrorContext, ...) ถึงจะได้ trace_id / span_id
+//
+//	slog.SetDefault(slog.New(eto.NewSlogHandler()))
+//	slog.InfoContext(ctx, "user created", "user_id", id)
+func NewSlogHandler() slog.Handler {
+	return &slogHandler{}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	b := LogBuilder{level: slogLevel(level)}
+	return std().logEnabled(b.zapLevel())
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	b := std().Log().FromContext(ctx).Msg(r.Message)
+	b.level = slogLevel(r.Level)
+	b.fields = append(b.fields, h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		b.fields = appendSlogAttr(b.fields, h.prefix, a)
+		return true
+	})
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		b.caller = fmt.Sprintf("%s:%d %s", filepath.Base(frame.File), frame.Line, shortFuncName(frame.Function))
+	}
+	b.Send()
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := &slogHandler{prefix: h.prefix, fields: append([]zap.Field(nil), h.fields...)}
+	for _, a := range attrs {
+		out.fields = appendSlogAttr(out.fields, h.prefix, a)
+	}
+	return out
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{prefix: h.prefix + name + ".", fields: h.fields}
+}
+
+func slogLevel(l slog.Level) LogLevel {
+	switch {
+	case l < slog.LevelInfo:
+		return levelDebug
+	case l < slog.LevelWarn:
+		return levelInfo
+	case l < slog.LevelError:
+		return levelWarn
+	default:
+		return levelError
+	}
+}
+
+// appendSlogAttr แปลง slog.Attr เป็น zap field ชนิดที่ zapFieldsToOtelAttrs ส่งต่อเข้า OTEL ได้
+// (duration / time เป็น string, group flatten เป็น key แบบมี prefix)
+func appendSlogAttr(fields []zap.Field, prefix string, a slog.Attr) []zap.Field {
+	v := a.Value.Resolve()
+	if a.Key == "" && v.Kind() != slog.KindGroup {
+		// attr ว่างให้ข้ามตามกติกาของ slog.Handler
+		return fields
+	}
+	key := prefix + a.Key
+
+	switch v.Kind() {
+	case slog.KindGroup:
+		p := prefix
+		if a.Key != "" {
+			p = key + "."
+		}
+		for _, ga := range v.Group() {
+			fields = appendSlogAttr(fields, p, ga)
+		}
+		return fields
+	case slog.KindString:
+		return append(fields, zap.String(key, v.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(key, v.Int64()))
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return append(fields, zap.Int64(key, int64(u)))
+		}
+		return append(fields, zap.String(key, v.String()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(key, v.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(key, v.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.String(key, v.Duration().String()))
+	case slog.KindTime:
+		return append(fields, zap.String(key, v.Time().Format(time.RFC3339Nano)))
+	default:
+		if err, ok := v.Any().(error); ok {
+			return append(fields, zap.String(key, err.Error()))
+		}
+		return append(fields, zap.String(key, fmt.Sprint(v.Any())))
+	}
+}
@@ -0,0 +1,129 @@
+package eto
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanAggregate สะสมสถิติของ operation เล็ก ๆ ที่เกิดถี่มาก (เช่น process ทีละ row)
+// แทนการสร้าง micro-span เป็นพัน ๆ ตัว แล้วสรุปเป็น span event เดียวตอน Done()
+type SpanAggregate struct {
+	ctx  context.Context
+	name string
+
+	mu     sync.Mutex
+	count  int64
+	errs   int64
+	total  time.Duration
+	min    time.Duration
+	max    time.Duration
+	closed bool
+}
+
+// AggregateSpan: เริ่มสะสม duration ของ operation ชื่อ name ภายใต้ span ใน ctx
+// ใช้แบบ:
+//
+//	agg := eto.AggregateSpan(ctx, "row.process")
+//	defer agg.Done()
+//	for _, row := range rows {
+//	    _ = agg.Time(func() error { return process(row) })
+//	}
+func AggregateSpan(ctx context.Context, name string) *SpanAggregate {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if name == "" {
+		name = "unnamed-aggregate"
+	}
+	return &SpanAggregate{
+		ctx:  ctx,
+		name: name,
+	}
+}
+
+// Start เริ่มจับเวลา 1 ครั้ง คืน stop func ไว้เรียกตอนจบ
+func (a *SpanAggregate) Start() func() {
+	start := time.Now()
+	return func() {
+		a.Observe(time.Since(start), nil)
+	}
+}
+
+// Time รัน fn แล้วนับ duration + error ให้อัตโนมัติ
+func (a *SpanAggregate) Time(fn func() error) error {
+	if fn == nil {
+		return nil
+	}
+	start := time.Now()
+	err := fn()
+	a.Observe(time.Since(start), err)
+	return err
+}
+
+// Observe บันทึกผลของ operation 1 ครั้ง (ใช้ตอนจับเวลาเอง)
+func (a *SpanAggregate) Observe(d time.Duration, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed {
+		return
+	}
+
+	a.count++
+	a.total += d
+	if a.count == 1 || d < a.min {
+		a.min = d
+	}
+	if d > a.max {
+		a.max = d
+	}
+	if err != nil {
+		a.errs++
+	}
+}
+
+// Done สรุปผลเป็น span event "aggregate" บน span ใน ctx + counter aggregate_operations_total
+// เรียกได้ครั้งเดียว ครั้งต่อไปไม่มีผล
+func (a *SpanAggregate) Done() {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return
+	}
+	a.closed = true
+	count, errs, total, minD, maxD := a.count, a.errs, a.total, a.min, a.max
+	a.mu.Unlock()
+
+	if count == 0 {
+		return
+	}
+
+	avg := total / time.Duration(count)
+
+	span := trace.SpanFromContext(a.ctx)
+	span.AddEvent("aggregate", trace.WithAttributes(
+		attribute.String("aggregate.name", a.name),
+		attribute.Int64("aggregate.count", count),
+		attribute.Int64("aggregate.error_count", errs),
+		attribute.Float64("aggregate.total_ms", durationMs(total)),
+		attribute.Float64("aggregate.avg_ms", durationMs(avg)),
+		attribute.Float64("aggregate.min_ms", durationMs(minD)),
+		attribute.Float64("aggregate.max_ms", durationMs(maxD)),
+	))
+
+	MetricCounter("aggregate_operations_total").
+		Attr("name", a.name).
+		Add(a.ctx, count)
+
+	MetricHistogram("aggregate_total_duration_ms").
+		Attr("name", a.name).
+		Record(a.ctx, durationMs(total))
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,51 @@
+package eto
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type spanBudgetKey struct{}
+
+// spanBudget จำนวน span ที่ request หนึ่ง (root span ที่สร้างผ่าน Trace()) ใช้ไปแล้ว (Config.MaxSpansPerRequest)
+// อยู่ใน ctx จึงตามไปทุก goroutine ที่ใช้ ctx ของ request เดียวกัน
+type spanBudget struct {
+	max        int64
+	used       atomic.Int64
+	suppressed atomic.Int64
+	root       trace.Span
+}
+
+func spanBudgetFrom(ctx context.Context) *spanBudget {
+	b, _ := ctx.Value(spanBudgetKey{}).(*spanBudget)
+	return b
+}
+
+func (b *spanBudget) take() bool {
+	return b.used.Add(1) <= b.max
+}
+
+// withSpanBudget ผูก budget ใหม่กับ ctx ของ root span (root นับเป็น span แรก)
+func (p *Provider) withSpanBudget(ctx context.Context, root trace.Span) context.Context {
+	b := &spanBudget{max: int64(p.cfg.MaxSpansPerRequest), root: root}
+	b.used.Store(1)
+	return context.WithValue(ctx, spanBudgetKey{}, b)
+}
+
+// suppressSpan นับ span ที่เกิน budget: attribute eto.spans_suppressed บน root span
+// (event "eto.span_budget_exceeded" ครั้งแรก) และ counter eto_spans_suppressed_total
+// คืน span ที่ไม่บันทึกอะไรแต่ถือ span context ของ parent ไว้ให้ log ยังผูก trace ได้
+func (p *Provider) suppressSpan(ctx context.Context, b *spanBudget) trace.Span {
+	n := b.suppressed.Add(1)
+	if n == 1 {
+		b.root.AddEvent("eto.span_budget_exceeded", trace.WithAttributes(attribute.Int64("eto.span_budget", b.max)))
+	}
+	b.root.SetAttributes(attribute.Int64("eto.spans_suppressed", n))
+	p.MetricCounter("eto_spans_suppressed_total").
+		Description("child spans dropped because the request exceeded MaxSpansPerRequest").
+		Add(ctx, 1)
+	return trace.SpanFromContext(trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx)))
+}
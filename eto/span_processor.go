@@ -0,0 +1,119 @@
+package eto
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// minDurationProcessor ทิ้ง span ที่สั้นกว่า min (ยกเว้น span ที่ status เป็น error)
+// ก่อนส่งต่อให้ processor ตัวจริง (ปกติคือ batcher)
+// หมายเหตุ: ถ้า parent ถูกทิ้งแต่ child ยาวพอ child จะยังถูกส่ง (เห็นเป็น orphan ใน backend)
+type minDurationProcessor struct {
+	next sdktrace.SpanProcessor
+	min  time.Duration
+}
+
+func newMinDurationProcessor(next sdktrace.SpanProcessor, min time.Duration) sdktrace.SpanProcessor {
+	if min <= 0 {
+		return next
+	}
+	return &minDurationProcessor{next: next, min: min}
+}
+
+func (p *minDurationProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *minDurationProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.Status().Code != codes.Error && s.EndTime().Sub(s.StartTime()) < p.min {
+		return
+	}
+	p.next.OnEnd(s)
+}
+
+func (p *minDurationProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *minDurationProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// leakDetector (debug mode) จำ span ที่ start แล้วแต่ยังไม่ End พร้อม stack ตอนสร้าง
+// ถ้า span ถูก GC ไปโดยไม่เคย End() จะ log warning พร้อม stack ให้ไปหา defer span.End() ที่หายไป
+type leakDetector struct {
+	pv    *Provider
+	mu    sync.Mutex
+	spans map[trace.SpanID][]uintptr
+}
+
+func newLeakDetector(pv *Provider) *leakDetector {
+	return &leakDetector{pv: pv, spans: map[trace.SpanID][]uintptr{}}
+}
+
+func (d *leakDetector) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	const maxDepth = 32
+	pcs := make([]uintptr, maxDepth)
+	n := runtime.Callers(3, pcs)
+
+	id := s.SpanContext().SpanID()
+	d.mu.Lock()
+	d.spans[id] = pcs[:n]
+	d.mu.Unlock()
+
+	name := s.Name()
+	traceID := s.SpanContext().TraceID()
+	runtime.SetFinalizer(s, func(sdktrace.ReadWriteSpan) {
+		d.mu.Lock()
+		pcs, leaked := d.spans[id]
+		delete(d.spans, id)
+		d.mu.Unlock()
+
+		if leaked {
+			reportLeakedSpan(d.pv.logger, name, traceID, id, pcs)
+		}
+	})
+}
+
+func (d *leakDetector) OnEnd(s sdktrace.ReadOnlySpan) {
+	d.mu.Lock()
+	delete(d.spans, s.SpanContext().SpanID())
+	d.mu.Unlock()
+}
+
+func (d *leakDetector) Shutdown(context.Context) error   { return nil }
+func (d *leakDetector) ForceFlush(context.Context) error { return nil }
+
+func reportLeakedSpan(logger *zap.Logger, name string, traceID trace.TraceID, spanID trace.SpanID, pcs []uintptr) {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+
+	if logger == nil {
+		log.Printf("eto: span %q (trace_id=%s span_id=%s) was garbage collected without End()\n%s",
+			name, traceID, spanID, sb.String())
+		return
+	}
+	logger.Warn("span was garbage collected without End()",
+		zap.String("span_name", name),
+		zap.String("trace_id", traceID.String()),
+		zap.String("span_id", spanID.String()),
+		zap.String("created_at", sb.String()),
+	)
+}
@@ -0,0 +1,104 @@
+package eto
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var registerSQLOnce sync.Once
+
+// TxBeginner คือ *sql.DB หรือ *sql.Conn (อะไรก็ได้ที่เปิด transaction ได้)
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// WithTx: รัน fn ภายใน transaction เดียว โดยมี span "db.transaction" ครอบทั้งก้อน
+// fn return error (หรือ panic) → rollback, ไม่งั้น commit
+// ctx ที่ส่งให้ fn มี span อยู่แล้ว ให้ใช้ ctx นี้กับ tx.QueryContext / ExecContext ข้างใน
+// ใช้แบบ:
+//
+//	err := eto.WithTx(ctx, db, func(ctx context.Context, tx *sql.Tx) error {
+//	    _, err := tx.ExecContext(ctx, "UPDATE ...")
+//	    return err
+//	})
+func WithTx(ctx context.Context, db TxBeginner, fn func(ctx context.Context, tx *sql.Tx) error) (err error) {
+	if db == nil {
+		return errors.New("eto.WithTx: db is nil")
+	}
+	if fn == nil {
+		return errors.New("eto.WithTx: fn is nil")
+	}
+
+	registerSQLOnce.Do(func() {
+		registerInstrumentation("sql", "", map[string]any{"helper": "WithTx"})
+	})
+
+	start := time.Now()
+	ctx, span := Trace().
+		Name("db.transaction").
+		FromContext(ctx).
+		Kind(trace.SpanKindClient).
+		Start()
+
+	outcome := "commit"
+	defer func() {
+		if err != nil {
+			std().recordError(span, err)
+			span.SetStatus(codes.Error, std().statusDescription(err))
+		}
+		span.SetAttributes(attribute.String("db.transaction.outcome", outcome))
+		span.End(std().spanEndOptions()...)
+
+		MetricCounter("db_transactions_total").
+			Attr("outcome", outcome).
+			Add(ctx, 1)
+
+		MetricHistogram("db_transaction_duration_ms").
+			Attr("outcome", outcome).
+			Record(ctx, float64(time.Since(start).Milliseconds()))
+	}()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		outcome = "begin_error"
+		return fmt.Errorf("eto.WithTx: begin: %w", err)
+	}
+	span.AddEvent("db.begin")
+
+	defer func() {
+		if p := recover(); p != nil {
+			outcome = "rollback"
+			_ = tx.Rollback()
+			span.AddEvent("db.rollback", trace.WithAttributes(attribute.String("reason", "panic")))
+			// defer ตัวแรกจะปิด span + บันทึก metrics ให้ก่อน panic ต่อ
+			err = fmt.Errorf("eto.WithTx: panic: %v", p)
+			panic(p)
+		}
+	}()
+
+	if err = fn(ctx, tx); err != nil {
+		outcome = "rollback"
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			span.AddEvent("db.rollback", trace.WithAttributes(attribute.String("error", rbErr.Error())))
+			return errors.Join(err, fmt.Errorf("eto.WithTx: rollback: %w", rbErr))
+		}
+		span.AddEvent("db.rollback")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		outcome = "commit_error"
+		span.AddEvent("db.commit", trace.WithAttributes(attribute.String("error", err.Error())))
+		return fmt.Errorf("eto.WithTx: commit: %w", err)
+	}
+	span.AddEvent("db.commit")
+	return nil
+}
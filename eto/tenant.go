@@ -0,0 +1,189 @@
+package eto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	otlploggrpc "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	otlpgrpc "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const tenantAttrKey = "tenant.id"
+
+// TenantExporter ปลายทางของ tenant หนึ่ง (collector แยก / token แยก)
+type TenantExporter struct {
+	Endpoint string            // ว่าง = ใช้ endpoint ของ signal นั้น (TracesEndpoint / LogsEndpoint / OtelEndpoint)
+	Headers  map[string]string // เช่น {"authorization": "Bearer <tenant-token>"}
+}
+
+// tenantOf คืน tenant ของ ctx ตาม Config.TenantFromContext ("" = ไม่มี / ไม่ได้ตั้ง hook)
+func (p *Provider) tenantOf(ctx context.Context) string {
+	if p.cfg.TenantFromContext == nil || ctx == nil {
+		return ""
+	}
+	return p.cfg.TenantFromContext(ctx)
+}
+
+// ---------- traces ----------
+
+// tenantSpanRouter ติด tenant.id ให้ทุก span ตอน start แล้วส่งไป processor ของ tenant นั้น
+// (tenant ที่ไม่มีใน TenantExporters → processor หลัก)
+type tenantSpanRouter struct {
+	pv      *Provider
+	def     sdktrace.SpanProcessor
+	tenants map[string]sdktrace.SpanProcessor
+}
+
+func (r *tenantSpanRouter) pick(tenant string) sdktrace.SpanProcessor {
+	if tp, ok := r.tenants[tenant]; ok {
+		return tp
+	}
+	return r.def
+}
+
+func (r *tenantSpanRouter) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	tenant := r.pv.tenantOf(parent)
+	if tenant != "" {
+		s.SetAttributes(attribute.String(tenantAttrKey, tenant))
+	}
+	r.pick(tenant).OnStart(parent, s)
+}
+
+func (r *tenantSpanRouter) OnEnd(s sdktrace.ReadOnlySpan) {
+	var tenant string
+	for _, kv := range s.Attributes() {
+		if kv.Key == tenantAttrKey {
+			tenant = kv.Value.AsString()
+			break
+		}
+	}
+	r.pick(tenant).OnEnd(s)
+}
+
+func (r *tenantSpanRouter) Shutdown(ctx context.Context) error {
+	errs := []error{r.def.Shutdown(ctx)}
+	for _, tp := range r.tenants {
+		errs = append(errs, tp.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+func (r *tenantSpanRouter) ForceFlush(ctx context.Context) error {
+	errs := []error{r.def.ForceFlush(ctx)}
+	for _, tp := range r.tenants {
+		errs = append(errs, tp.ForceFlush(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// ---------- logs ----------
+
+// tenantLogRouter เหมือน tenantSpanRouter แต่สำหรับ log record
+type tenantLogRouter struct {
+	pv      *Provider
+	def     sdklog.Processor
+	tenants map[string]sdklog.Processor
+}
+
+func (r *tenantLogRouter) OnEmit(ctx context.Context, rec *sdklog.Record) error {
+	tenant := r.pv.tenantOf(ctx)
+	if tenant == "" {
+		return r.def.OnEmit(ctx, rec)
+	}
+	rec.AddAttributes(otellog.String(tenantAttrKey, tenant))
+	if tp, ok := r.tenants[tenant]; ok {
+		return tp.OnEmit(ctx, rec)
+	}
+	return r.def.OnEmit(ctx, rec)
+}
+
+func (r *tenantLogRouter) Shutdown(ctx context.Context) error {
+	errs := []error{r.def.Shutdown(ctx)}
+	for _, tp := range r.tenants {
+		errs = append(errs, tp.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+func (r *tenantLogRouter) ForceFlush(ctx context.Context) error {
+	errs := []error{r.def.ForceFlush(ctx)}
+	for _, tp := range r.tenants {
+		errs = append(errs, tp.ForceFlush(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// ---------- Init wiring ----------
+
+// newTenantSpanProcessor ห่อ processor หลักด้วย router ถ้ามีการตั้ง TenantFromContext
+func (p *Provider) newTenantSpanProcessor(ctx context.Context, def sdktrace.SpanProcessor) (sdktrace.SpanProcessor, error) {
+	cfg := p.cfg
+	if cfg.TenantFromContext == nil {
+		return def, nil
+	}
+
+	router := &tenantSpanRouter{pv: p, def: def, tenants: map[string]sdktrace.SpanProcessor{}}
+	for tenant, te := range cfg.TenantExporters {
+		endpoint := te.Endpoint
+		if endpoint == "" {
+			endpoint = cfg.tracesEndpoint()
+		}
+		exp, err := otlpgrpc.New(
+			ctx,
+			otlpgrpc.WithEndpoint(endpoint),
+			otlpgrpc.WithInsecure(),
+			otlpgrpc.WithDialOption(cfg.grpcDialOptions()...),
+			otlpgrpc.WithHeaders(mergeHeaders(cfg.OTLPHeaders, te.Headers)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w: trace exporter for tenant %q: %w", ErrExporterDown, tenant, err)
+		}
+		router.tenants[tenant] = newMinDurationProcessor(sdktrace.NewBatchSpanProcessor(exp), cfg.MinSpanDuration)
+	}
+	return router, nil
+}
+
+// newTenantLogProcessor ห่อ log processor หลักด้วย router ถ้ามีการตั้ง TenantFromContext
+func (p *Provider) newTenantLogProcessor(ctx context.Context, def sdklog.Processor) (sdklog.Processor, error) {
+	cfg := p.cfg
+	if cfg.TenantFromContext == nil {
+		return def, nil
+	}
+
+	router := &tenantLogRouter{pv: p, def: def, tenants: map[string]sdklog.Processor{}}
+	for tenant, te := range cfg.TenantExporters {
+		endpoint := te.Endpoint
+		if endpoint == "" {
+			endpoint = cfg.logsEndpoint()
+		}
+		exp, err := otlploggrpc.New(
+			ctx,
+			otlploggrpc.WithEndpoint(endpoint),
+			otlploggrpc.WithInsecure(),
+			otlploggrpc.WithDialOption(cfg.grpcDialOptions()...),
+			otlploggrpc.WithHeaders(mergeHeaders(cfg.OTLPHeaders, te.Headers)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w: log exporter for tenant %q: %w", ErrExporterDown, tenant, err)
+		}
+		router.tenants[tenant] = sdklog.NewBatchProcessor(exp)
+	}
+	return router, nil
+}
+
+// mergeHeaders รวม OTLPHeaders กลางกับ header ของ tenant (ของ tenant ทับ key เดียวกัน)
+func mergeHeaders(base, override map[string]string) map[string]string {
+	out := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range override {
+		out[k] = v
+	}
+	return out
+}
@@ -4,26 +4,41 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type TraceBuilder struct {
+	pv         *Provider
 	name       string
 	ctx        context.Context
 	attrs      []attribute.KeyValue
+	links      []trace.Link
 	kind       trace.SpanKind
 	recordErr  bool
 	setStatus  bool
+	worker     bool
 	tracerName string
 }
 
 type SpanScope struct {
 	ctx  context.Context
 	span trace.Span
+	pv   *Provider
+
+	mu     sync.Mutex
+	errs   map[string]*repeatedErr  // key = type + message ของ error ที่ record ไปแล้ว
+	phases map[string]time.Duration // เวลารวมต่อ phase ของ Phase()
+}
+
+type repeatedErr struct {
+	typ   string
+	msg   string
+	count int
 }
 
 func (s *SpanScope) Ctx() context.Context {
@@ -37,14 +52,89 @@ func (s *SpanScope) Span() trace.Span {
 	return s.span
 }
 
+// RecordError บันทึก error ลง span พร้อม attribute เพิ่ม (เช่น retryable, code)
+// error เดิมซ้ำ (type + message เดียวกัน) จะถูกนับแทนการสร้าง event ใหม่ แล้วสรุปเป็น event
+// "exception.repeated" (exception.count) ตอน Done() กัน retry loop สร้าง event เป็นร้อย
+func (s *SpanScope) RecordError(err error, attrs ...attribute.KeyValue) {
+	if s == nil || s.span == nil || err == nil {
+		return
+	}
+	typ := errorType(err)
+	key := typ + "\x00" + err.Error()
+
+	s.mu.Lock()
+	if s.errs == nil {
+		s.errs = map[string]*repeatedErr{}
+	}
+	if e, ok := s.errs[key]; ok {
+		e.count++
+		s.mu.Unlock()
+		return
+	}
+	// เก็บข้อความที่ผ่าน sanitizer แล้วไว้ใช้ใน exception.repeated
+	s.errs[key] = &repeatedErr{typ: typ, msg: s.pv.statusDescription(err), count: 1}
+	s.mu.Unlock()
+
+	s.pv.recordError(s.span, err, attrs...)
+}
+
+// Phase จับเวลาช่วงหนึ่งของงานเป็น attribute "<name>_ms" บน span เดียวกัน แทนการสร้าง child span
+// (trace สั้นกระชับสำหรับ endpoint ง่าย ๆ) เรียก phase ชื่อเดิมซ้ำ = รวมเวลา
+//
+//	stop := scope.Phase("db")
+//	rows, err := repo.List(ctx)
+//	stop()
+func (s *SpanScope) Phase(name string) func() {
+	if s == nil || s.span == nil || name == "" {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		s.mu.Lock()
+		if s.phases == nil {
+			s.phases = map[string]time.Duration{}
+		}
+		s.phases[name] += elapsed
+		total := s.phases[name]
+		s.mu.Unlock()
+
+		s.span.SetAttributes(attribute.Float64(name+"_ms", durationMs(total)))
+	}
+}
+
 func (s *SpanScope) Done() {
 	if s != nil && s.span != nil {
-		s.span.End()
+		s.flushRepeatedErrs()
+		s.pv.recordGCPause(s.ctx, s.span)
+		s.span.End(s.pv.spanEndOptions()...)
 	}
 }
 
+func (s *SpanScope) flushRepeatedErrs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.errs {
+		if e.count < 2 {
+			continue
+		}
+		s.span.AddEvent("exception.repeated", trace.WithAttributes(
+			attribute.String("exception.type", e.typ),
+			attribute.String("exception.message", e.msg),
+			attribute.Int("exception.count", e.count),
+		))
+	}
+	s.errs = nil
+}
+
 func Trace() *TraceBuilder {
+	return std().Trace()
+}
+
+// Trace สร้าง span builder ที่ใช้ TracerProvider ของ Provider ตัวนี้
+func (p *Provider) Trace() *TraceBuilder {
 	return &TraceBuilder{
+		pv:         p,
 		ctx:        context.Background(),
 		kind:       trace.SpanKindInternal,
 		recordErr:  true,
@@ -100,6 +190,20 @@ func (b *TraceBuilder) Attrs(attrs ...attribute.KeyValue) *TraceBuilder {
 	return b
 }
 
+// Link ผูก span นี้กับ span อื่น (เช่น trace ต้นทางของงาน async) โดยไม่ต้องเป็น parent
+func (b *TraceBuilder) Link(sc trace.SpanContext, attrs ...attribute.KeyValue) *TraceBuilder {
+	if sc.IsValid() {
+		b.links = append(b.links, trace.Link{SpanContext: sc, Attributes: attrs})
+	}
+	return b
+}
+
+// Worker ใส่ worker.id (จาก WithWorkerID) และ goroutine.id ลง span ปิดไว้เป็นค่าเริ่มต้นเพราะมี overhead
+func (b *TraceBuilder) Worker(enable bool) *TraceBuilder {
+	b.worker = enable
+	return b
+}
+
 func (b *TraceBuilder) RecordError(enable bool) *TraceBuilder {
 	b.recordErr = enable
 	return b
@@ -114,10 +218,41 @@ func (b *TraceBuilder) Start() (context.Context, trace.Span) {
 	if b.name == "" {
 		b.name = "unnamed-span"
 	}
-	tr := otel.Tracer(b.tracerName)
-	ctx, span := tr.Start(b.ctx, b.name, trace.WithSpanKind(b.kind))
+	budget := spanBudgetFrom(b.ctx)
+	if budget != nil && !budget.take() {
+		return b.ctx, b.pv.suppressSpan(b.ctx, budget)
+	}
+	tr := b.pv.tracer(b.tracerName)
+	opts := []trace.SpanStartOption{trace.WithSpanKind(b.kind)}
+	if b.pv.cfg.ClockSource != nil {
+		opts = append(opts, trace.WithTimestamp(b.pv.now()))
+	}
+	if defaults := b.pv.cfg.DefaultAttrsByKind[b.kind]; len(defaults) > 0 {
+		// ใส่ก่อน attribute ของ builder เพื่อให้ค่าที่ builder ใส่เองชนะเมื่อ key ซ้ำ
+		opts = append(opts, trace.WithAttributes(defaults...))
+	}
 	if len(b.attrs) > 0 {
-		span.SetAttributes(b.attrs...)
+		b.pv.hashAttrs(b.attrs)
+		// ส่งตอน start เพื่อให้ sampler (SamplerHook) เห็น attribute ด้วย
+		opts = append(opts, trace.WithAttributes(b.attrs...))
+	}
+	if len(b.links) > 0 {
+		opts = append(opts, trace.WithLinks(b.links...))
+	}
+	ctx, span := tr.Start(b.ctx, b.name, opts...)
+	if budget == nil && b.pv.cfg.MaxSpansPerRequest > 0 && span.IsRecording() {
+		ctx = b.pv.withSpanBudget(ctx, span)
+	}
+	if b.worker {
+		workerID, gid := workerInfo(ctx)
+		if workerID != "" {
+			span.SetAttributes(attribute.String("worker.id", workerID))
+		}
+		span.SetAttributes(attribute.Int64("goroutine.id", gid))
+	}
+	// principal จาก WithPrincipal (หรือ baggage ขาเข้าเมื่อ TrustInboundPrincipal) → enduser.id
+	if attrs := b.pv.principalAttrs(ctx); len(attrs) > 0 {
+		span.SetAttributes(attrs...)
 	}
 	return ctx, span
 }
@@ -127,6 +262,7 @@ func (b *TraceBuilder) StartScope() *SpanScope {
 	return &SpanScope{
 		ctx:  ctx,
 		span: span,
+		pv:   b.pv,
 	}
 }
 
@@ -136,15 +272,17 @@ func (b *TraceBuilder) Run(fn func(ctx context.Context) error) error {
 	}
 
 	ctx, span := b.Start()
-	defer span.End()
+	// อ่านนาฬิกาตอน span จบจริง ไม่ใช่ตอนประกาศ defer
+	defer func() { span.End(b.pv.spanEndOptions()...) }()
 
 	err := fn(ctx)
+	b.pv.recordGCPause(ctx, span)
 	if err != nil {
 		if b.recordErr {
-			span.RecordError(err)
+			b.pv.recordError(span, err)
 		}
 		if b.setStatus {
-			span.SetStatus(codes.Error, err.Error())
+			span.SetStatus(codes.Error, b.pv.statusDescription(err))
 		}
 	}
 	return err
@@ -0,0 +1,158 @@
+package eto
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TransportHooks จุดต่อ instrumentation ของ protocol ที่ eto ไม่รู้จัก (เช่น binary TCP gateway)
+// แบบเดียวกับ stats.Handler ของ gRPC: เรียก OnStart / OnError / OnEnd ต่อหนึ่ง call
+// แล้วได้ span + metric ตาม convention เดียวกับ middleware อื่นของ eto
+//
+//	hooks := eto.NewTransportHooks("tcp-gateway", trace.SpanKindServer)
+//	ctx = hooks.OnStart(ctx, eto.TransportInfo{Method: frame.Op, Peer: conn.RemoteAddr().String(), Carrier: frameHeaders})
+//	resp, err := handle(ctx, frame)
+//	hooks.OnError(ctx, err)
+//	hooks.OnEnd(ctx, eto.TransportStats{SentBytes: int64(len(resp)), ReceivedBytes: int64(len(frame.Body))})
+//
+// metric: transport_duration_ms / transport_sent_bytes / transport_received_bytes {transport, method, kind, status}
+type TransportHooks struct {
+	pv        *Provider
+	transport string
+	kind      trace.SpanKind
+}
+
+// TransportInfo ข้อมูลของ call ที่รู้ตอนเริ่ม
+type TransportInfo struct {
+	Method string // ชื่อ operation / opcode
+	Peer   string // address ของอีกฝั่ง (ว่างได้)
+	Attrs  []attribute.KeyValue
+	// Carrier header ของ protocol (ว่างได้): server = extract parent, client = inject trace context ให้ปลายทาง
+	Carrier propagation.TextMapCarrier
+}
+
+// TransportStats ผลของ call ที่รู้ตอนจบ
+type TransportStats struct {
+	Status        string // ว่าง = "ok" หรือ "error" ถ้าเคยเรียก OnError
+	SentBytes     int64
+	ReceivedBytes int64
+}
+
+type transportCallKey struct{}
+
+type transportCall struct {
+	span   trace.Span
+	method string
+	start  time.Time
+	failed bool
+}
+
+// NewTransportHooks สร้าง hooks ของ transport ชื่อนี้ (kind = SpanKindServer / SpanKindClient / Producer / Consumer)
+func NewTransportHooks(transport string, kind trace.SpanKind) *TransportHooks {
+	return std().NewTransportHooks(transport, kind)
+}
+
+// NewTransportHooks ของ Provider ตัวนี้ (ดู eto.NewTransportHooks)
+func (p *Provider) NewTransportHooks(transport string, kind trace.SpanKind) *TransportHooks {
+	if transport == "" {
+		transport = "custom"
+	}
+	return &TransportHooks{pv: p, transport: transport, kind: kind}
+}
+
+// OnStart เปิด span "<transport>.<method>" แล้วคืน ctx ที่ต้องส่งต่อให้ OnError / OnEnd
+func (h *TransportHooks) OnStart(ctx context.Context, info TransportInfo) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	remote := h.kind == trace.SpanKindServer || h.kind == trace.SpanKindConsumer
+	if info.Carrier != nil && remote && h.pv.propagator != nil {
+		ctx = h.pv.propagator.Extract(ctx, info.Carrier)
+	}
+	method := info.Method
+	if method == "" {
+		method = "unknown"
+	}
+
+	b := h.pv.Trace().
+		Name(h.transport+"."+method).
+		FromContext(ctx).
+		Kind(h.kind).
+		Attr("rpc.system", h.transport).
+		Attr("rpc.method", method).
+		Attrs(info.Attrs...)
+	if info.Peer != "" {
+		b = b.Attr("network.peer.address", info.Peer)
+	}
+	ctx, span := b.Start()
+
+	if info.Carrier != nil && !remote && h.pv.propagator != nil {
+		h.pv.propagator.Inject(ctx, info.Carrier)
+	}
+	return context.WithValue(ctx, transportCallKey{}, &transportCall{span: span, method: method, start: time.Now()})
+}
+
+// OnError บันทึก error ของ call (เรียกได้หลายครั้ง, nil = ไม่ทำอะไร)
+func (h *TransportHooks) OnError(ctx context.Context, err error) {
+	call := transportCallFrom(ctx)
+	if call == nil || err == nil {
+		return
+	}
+	call.failed = true
+	h.pv.recordError(call.span, err)
+	call.span.SetStatus(codes.Error, h.pv.statusDescription(err))
+}
+
+// OnEnd ปิด span และบันทึก metric ของ call
+func (h *TransportHooks) OnEnd(ctx context.Context, stats TransportStats) {
+	call := transportCallFrom(ctx)
+	if call == nil {
+		return
+	}
+	status := stats.Status
+	if status == "" {
+		status = "ok"
+		if call.failed {
+			status = "error"
+		}
+	}
+	call.span.SetAttributes(
+		attribute.String("rpc.status", status),
+		attribute.Int64("rpc.sent_bytes", stats.SentBytes),
+		attribute.Int64("rpc.received_bytes", stats.ReceivedBytes),
+	)
+	h.pv.recordGCPause(ctx, call.span)
+	call.span.End(h.pv.spanEndOptions()...)
+
+	attrs := []attribute.KeyValue{
+		attribute.String("transport", h.transport),
+		attribute.String("method", call.method),
+		attribute.String("kind", h.kind.String()),
+		attribute.String("status", status),
+	}
+	h.pv.MetricHistogram("transport_duration_ms").
+		Unit("ms").
+		Attrs(attrs...).
+		Record(ctx, durationMs(time.Since(call.start)))
+	h.pv.MetricHistogram("transport_sent_bytes").
+		Unit("By").
+		Attrs(attrs...).
+		Record(ctx, float64(stats.SentBytes))
+	h.pv.MetricHistogram("transport_received_bytes").
+		Unit("By").
+		Attrs(attrs...).
+		Record(ctx, float64(stats.ReceivedBytes))
+}
+
+func transportCallFrom(ctx context.Context) *transportCall {
+	if ctx == nil {
+		return nil
+	}
+	call, _ := ctx.Value(transportCallKey{}).(*transportCall)
+	return call
+}
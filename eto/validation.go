@@ -0,0 +1,77 @@
+package eto
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ValidationFailure field ที่ไม่ผ่าน validation 1 ตัว (ไม่เก็บค่าที่ client ส่งมา กัน PII หลุดไปกับ telemetry)
+type ValidationFailure struct {
+	Field string // เช่น "CreateUserRequest.Email"
+	Rule  string // เช่น "required", "email", "min"
+	Param string // parameter ของ rule เช่น "8" ของ min=8 (ว่างได้)
+}
+
+// ValidationFailures แปลง error จาก go-playground/validator (รวมที่ c.ShouldBind ของ gin คืน)
+// เป็น []ValidationFailure; error ชนิดอื่นคืน nil
+func ValidationFailures(err error) []ValidationFailure {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+	out := make([]ValidationFailure, 0, len(verrs))
+	for _, fe := range verrs {
+		out = append(out, ValidationFailure{Field: fe.Namespace(), Rule: fe.Tag(), Param: fe.Param()})
+	}
+	return out
+}
+
+// RecordValidationError บันทึก validation error ลง span ปัจจุบันและ metric แทนการ log 400 แบบไม่มีโครงสร้าง
+// คืน false ถ้า err ไม่ใช่ validation error (ไม่ได้บันทึกอะไร)
+//
+//	if err := c.ShouldBindJSON(&req); err != nil {
+//	    eto.RecordValidationError(c.Request.Context(), err)
+//	    c.JSON(http.StatusBadRequest, ...)
+//	}
+func RecordValidationError(ctx context.Context, err error) bool {
+	failures := ValidationFailures(err)
+	if len(failures) == 0 {
+		return false
+	}
+	RecordValidationFailures(ctx, failures...)
+	return true
+}
+
+// RecordValidationFailures สำหรับ validation library อื่นที่แปลงผลเป็น ValidationFailure เอง
+// ได้ span event "validation.failure" ต่อ field และ counter validation_failures_total{field, rule}
+func RecordValidationFailures(ctx context.Context, failures ...ValidationFailure) {
+	if len(failures) == 0 {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	span := trace.SpanFromContext(ctx)
+	for _, f := range failures {
+		if span.IsRecording() {
+			attrs := []attribute.KeyValue{
+				attribute.String("validation.field", f.Field),
+				attribute.String("validation.rule", f.Rule),
+			}
+			if f.Param != "" {
+				attrs = append(attrs, attribute.String("validation.param", f.Param))
+			}
+			span.AddEvent("validation.failure", trace.WithAttributes(attrs...))
+		}
+
+		MetricCounter("validation_failures_total").
+			Attr("field", f.Field).
+			Attr("rule", f.Rule).
+			Add(ctx, 1)
+	}
+	span.SetAttributes(attribute.Int("validation.failure_count", len(failures)))
+}
@@ -0,0 +1,106 @@
+package eto
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WorkBuilder งานหนึ่งหน่วยที่ได้ span + log + metrics ในการเรียกครั้งเดียว แทน boilerplate สามชุดในทุก handler
+//
+//	err := eto.Work(ctx, "order.create").
+//		Attr("channel", "web").
+//		Counter("order_create_total").
+//		Histogram("order_create_duration_ms").
+//		Run(func(ctx context.Context) error { return svc.Create(ctx, req) })
+//
+// ได้ span ชื่อ name (record error + status), log debug ตอนเริ่ม / จบ, counter และ histogram ตามชื่อที่ใส่
+// (attribute ของ Attr + status = success / error) ไม่ใส่ชื่อ = ไม่ส่ง metric ตัวนั้น
+type WorkBuilder struct {
+	pv        *Provider
+	ctx       context.Context
+	name      string
+	attrs     []attribute.KeyValue
+	counter   string
+	histogram string
+}
+
+// Work สร้าง unit of work บน default provider
+func Work(ctx context.Context, name string) *WorkBuilder {
+	return std().Work(ctx, name)
+}
+
+// Work สร้าง unit of work ที่ใช้ Provider ตัวนี้
+func (p *Provider) Work(ctx context.Context, name string) *WorkBuilder {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &WorkBuilder{pv: p, ctx: ctx, name: name}
+}
+
+// Attr ใส่ทั้ง span, log และ metrics ของงานนี้ (ระวัง cardinality ของ metrics: อย่าใส่ id)
+func (b *WorkBuilder) Attr(key string, val any) *WorkBuilder {
+	b.attrs = append(b.attrs, anyToAttr(key, val))
+	return b
+}
+
+// Counter ชื่อ counter ที่นับทุกครั้งที่งานจบ (แยกตาม status)
+func (b *WorkBuilder) Counter(name string) *WorkBuilder {
+	b.counter = name
+	return b
+}
+
+// Histogram ชื่อ histogram ของระยะเวลางาน (มิลลิวินาที, แยกตาม status)
+func (b *WorkBuilder) Histogram(name string) *WorkBuilder {
+	b.histogram = name
+	return b
+}
+
+// Run เรียก fn ด้วย ctx ที่มี span ของงานนี้ แล้วคืน error ของ fn
+func (b *WorkBuilder) Run(fn func(ctx context.Context) error) error {
+	if fn == nil {
+		return errors.New("eto.Work().Run: fn is nil")
+	}
+
+	start := time.Now()
+	var err error
+	_ = b.pv.Trace().
+		Name(b.name).
+		FromContext(b.ctx).
+		Attrs(b.attrs...).
+		Run(func(ctx context.Context) error {
+			b.log(ctx).Msg(b.name + " started").Send()
+			err = fn(ctx)
+			elapsed := durationMs(time.Since(start))
+
+			status := "success"
+			if err != nil {
+				status = "error"
+			}
+			b.log(ctx).
+				Msg(b.name+" finished").
+				Field("status", status).
+				Field("duration_ms", elapsed).
+				Err(err).
+				Send()
+
+			if b.counter != "" {
+				b.pv.MetricCounter(b.counter).Attrs(b.attrs...).Attr("status", status).Add(ctx, 1)
+			}
+			if b.histogram != "" {
+				b.pv.MetricHistogram(b.histogram).Attrs(b.attrs...).Attr("status", status).Record(ctx, elapsed)
+			}
+			return err
+		})
+	return err
+}
+
+func (b *WorkBuilder) log(ctx context.Context) *LogBuilder {
+	l := b.pv.Log().FromContext(ctx).Debug().Field("work", b.name)
+	for _, kv := range b.attrs {
+		l.Field(string(kv.Key), kv.Value.AsInterface())
+	}
+	return l
+}
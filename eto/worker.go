@@ -0,0 +1,48 @@
+package eto
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+)
+
+const workerLabel = "worker.id"
+
+// WithWorkerID: ติด worker id ให้ goroutine ปัจจุบัน (ผ่าน pprof labels → เห็นใน profile ด้วย)
+// builder ที่เปิด .Worker(true) จะใส่ worker.id ลง span / log ให้
+// ใช้แบบ (ใน consumer pool): ctx = eto.WithWorkerID(ctx, fmt.Sprintf("consumer-%d", i))
+func WithWorkerID(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = pprof.WithLabels(ctx, pprof.Labels(workerLabel, id))
+	pprof.SetGoroutineLabels(ctx)
+	return ctx
+}
+
+// workerInfo คืน worker.id จาก pprof label (ถ้ามี) และ goroutine id ปัจจุบัน
+func workerInfo(ctx context.Context) (string, int64) {
+	var workerID string
+	if ctx != nil {
+		workerID, _ = pprof.Label(ctx, workerLabel)
+	}
+	return workerID, goroutineID()
+}
+
+// goroutineID อ่านจาก header ของ runtime.Stack ("goroutine 123 [running]:")
+// แพงพอสมควร ใช้เฉพาะตอน builder เปิด Worker(true)
+func goroutineID() int64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	line := bytes.TrimPrefix(buf[:n], []byte("goroutine "))
+	if idx := bytes.IndexByte(line, ' '); idx > 0 {
+		line = line[:idx]
+	}
+	id, err := strconv.ParseInt(string(line), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,82 @@
+package eto
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	xrayTraceHeader = "X-Amzn-Trace-Id"
+	xrayVersion     = "1"
+)
+
+// xrayPropagator อ่าน / เขียน header X-Amzn-Trace-Id ของ AWS X-Ray (Lambda, ALB, API Gateway)
+// รูปแบบ Root=1-<epoch 8 hex>-<24 hex>;Parent=<16 hex>;Sampled=<0|1>
+// ฝั่ง extract ไม่ทับ span context ที่ได้จาก traceparent แล้ว (W3C ชนะเสมอ)
+type xrayPropagator struct{}
+
+var _ propagation.TextMapPropagator = xrayPropagator{}
+
+func (xrayPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	tid := sc.TraceID().String()
+	sampled := "0"
+	if sc.IsSampled() {
+		sampled = "1"
+	}
+	carrier.Set(xrayTraceHeader, "Root="+xrayVersion+"-"+tid[:8]+"-"+tid[8:]+";Parent="+sc.SpanID().String()+";Sampled="+sampled)
+}
+
+func (xrayPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	if trace.SpanContextFromContext(ctx).IsRemote() {
+		return ctx
+	}
+	h := carrier.Get(xrayTraceHeader)
+	if h == "" {
+		return ctx
+	}
+
+	var cfg trace.SpanContextConfig
+	var haveTrace, haveParent bool
+	for _, part := range strings.Split(h, ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "Root":
+			// 1-5759e988-bd862e3fe1be46a994272793 → 5759e988bd862e3fe1be46a994272793
+			ver, rest, ok := strings.Cut(v, "-")
+			if !ok || ver != xrayVersion {
+				return ctx
+			}
+			id, err := trace.TraceIDFromHex(strings.Replace(rest, "-", "", 1))
+			if err != nil {
+				return ctx
+			}
+			cfg.TraceID, haveTrace = id, true
+		case "Parent":
+			id, err := trace.SpanIDFromHex(v)
+			if err != nil {
+				return ctx
+			}
+			cfg.SpanID, haveParent = id, true
+		case "Sampled":
+			if v == "1" {
+				cfg.TraceFlags = trace.FlagsSampled
+			}
+		}
+	}
+	if !haveTrace || !haveParent {
+		return ctx
+	}
+	cfg.Remote = true
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(cfg))
+}
+
+func (xrayPropagator) Fields() []string {
+	return []string{xrayTraceHeader}
+}
@@ -0,0 +1,236 @@
+package eto
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// zapCore ส่ง entry ของ *zap.Logger ที่ service สร้างเองเข้า OTEL LoggerProvider ของ eto
+type zapCore struct {
+	zapcore.LevelEnabler
+	fields []zapcore.Field
+}
+
+// zapCtxKey key ของ field ที่ ZapContext สร้าง (ชนิด SkipType: core อื่นใน tee จะไม่พิมพ์ออกมา)
+const zapCtxKey = "eto.ctx"
+
+// NewZapCore คืน zapcore.Core ที่ส่ง log เข้า OTEL logs ของ default provider (หา provider ตอนเขียน
+// จึงสร้างก่อน eto.Init ได้) ใช้คู่กับ core เดิมผ่าน zapcore.NewTee
+// ส่ง ctx มาด้วย eto.ZapContext เพื่อให้ log ผูกกับ trace (trace_id / span_id)
+//
+//	logger := zap.New(zapcore.NewTee(existing.Core(), eto.NewZapCore()))
+//	logger.Info("order created", eto.ZapContext(ctx), zap.String("order_id", id))
+func NewZapCore() zapcore.Core {
+	return &zapCore{LevelEnabler: zapcore.DebugLevel}
+}
+
+// ZapContext แนบ ctx ไปกับ entry ของ zap ให้ NewZapCore ดึง trace / span ปัจจุบัน
+// ใส่ตอนเรียก log หรือ logger.With(eto.ZapContext(ctx)) ก็ได้ core อื่นจะข้าม field นี้
+func ZapContext(ctx context.Context) zap.Field {
+	return zap.Field{Key: zapCtxKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+// ZapTraceFields คืน trace_id / span_id ของ ctx เป็น zap field สำหรับ core เดิม (console / file)
+// ที่ต้องการ id เดียวกับที่ OTEL logs ได้ (ctx ไม่มี span = nil)
+func ZapTraceFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	}
+}
+
+// zapFieldsCtx หา ctx ที่แนบด้วย ZapContext (ตัวหลังสุดชนะ)
+func zapFieldsCtx(ctx context.Context, fields []zapcore.Field) context.Context {
+	for _, f := range fields {
+		if f.Key != zapCtxKey || f.Type != zapcore.SkipType {
+			continue
+		}
+		if c, ok := f.Interface.(context.Context); ok && c != nil {
+			ctx = c
+		}
+	}
+	return ctx
+}
+
+func (c *zapCore) With(fields []zapcore.Field) zapcore.Core {
+	out := &zapCore{LevelEnabler: c.LevelEnabler}
+	out.fields = append(append(out.fields, c.fields...), fields...)
+	return out
+}
+
+func (c *zapCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) && std().otelLevel.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *zapCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	p := std()
+	if p.otelLogger == nil {
+		return nil
+	}
+
+	var rec otellog.Record
+	sev, text := zapSeverity(ent.Level)
+	rec.SetSeverity(sev)
+	rec.SetSeverityText(text)
+	rec.SetBody(otellog.StringValue(ent.Message))
+	rec.SetTimestamp(ent.Time.UTC())
+	rec.SetObservedTimestamp(p.now().UTC())
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	rec.AddAttributes(mapToOtelAttrs(enc.Fields)...)
+
+	ctx := zapFieldsCtx(zapFieldsCtx(context.Background(), c.fields), fields)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		rec.AddAttributes(
+			otellog.String("trace_id", sc.TraceID().String()),
+			otellog.String("span_id", sc.SpanID().String()),
+		)
+	}
+
+	if ent.LoggerName != "" {
+		rec.AddAttributes(otellog.String("logger", ent.LoggerName))
+	}
+	if ent.Caller.Defined {
+		rec.AddAttributes(otellog.String("caller", ent.Caller.TrimmedPath()))
+	}
+	// zap ใส่ stack ไว้ที่ entry (AddStacktrace / DPanic ขึ้นไป) → exception.stacktrace ตาม semconv
+	if ent.Stack != "" {
+		rec.AddAttributes(otellog.String("exception.stacktrace", ent.Stack))
+	}
+
+	p.otelLogger.Emit(ctx, rec)
+	// DPanic / Panic / Fatal: zap จะ panic / os.Exit ต่อทันที ส่งออกเลยไม่รอ batch
+	if ent.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+	return nil
+}
+
+// zapCoreFlushTimeout เวลาสูงสุดที่ Sync รอ OTEL logs ส่งออก (zap เรียก Sync ก่อน os.Exit ของ Fatal)
+const zapCoreFlushTimeout = 2 * time.Second
+
+func (c *zapCore) Sync() error {
+	p := std()
+	if p.lp == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), zapCoreFlushTimeout)
+	defer cancel()
+	return p.lp.ForceFlush(ctx)
+}
+
+// zapSeverity แปลง level ของ zap เป็น OTEL severity รวม level ที่ LogBuilder ไม่มี (DPanic / Panic / Fatal)
+// ให้ alert ที่ตั้งตาม severity จับได้ถูก
+func zapSeverity(l zapcore.Level) (otellog.Severity, string) {
+	switch l {
+	case zapcore.DebugLevel:
+		return otellog.SeverityDebug, "DEBUG"
+	case zapcore.InfoLevel:
+		return otellog.SeverityInfo, "INFO"
+	case zapcore.WarnLevel:
+		return otellog.SeverityWarn, "WARN"
+	case zapcore.ErrorLevel:
+		return otellog.SeverityError, "ERROR"
+	case zapcore.DPanicLevel:
+		return otellog.SeverityError4, "DPANIC"
+	case zapcore.PanicLevel:
+		return otellog.SeverityFatal, "PANIC"
+	case zapcore.FatalLevel:
+		return otellog.SeverityFatal4, "FATAL"
+	default:
+		if l < zapcore.DebugLevel {
+			return otellog.SeverityTrace, strings.ToUpper(l.String())
+		}
+		return otellog.SeverityInfo, strings.ToUpper(l.String())
+	}
+}
+
+// mapToOtelAttrs แปลงผลของ zapcore.MapObjectEncoder เป็น OTEL log attributes (sort key ให้ลำดับคงที่)
+func mapToOtelAttrs(m map[string]any) []otellog.KeyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]otellog.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, otellog.KeyValue{Key: k, Value: anyToLogValue(m[k])})
+	}
+	return out
+}
+
+func anyToLogValue(v any) otellog.Value {
+	switch x := v.(type) {
+	case nil:
+		return otellog.Value{}
+	case string:
+		return otellog.StringValue(x)
+	case bool:
+		return otellog.BoolValue(x)
+	case int:
+		return otellog.IntValue(x)
+	case int8:
+		return otellog.Int64Value(int64(x))
+	case int16:
+		return otellog.Int64Value(int64(x))
+	case int32:
+		return otellog.Int64Value(int64(x))
+	case int64:
+		return otellog.Int64Value(x)
+	case uint8:
+		return otellog.Int64Value(int64(x))
+	case uint16:
+		return otellog.Int64Value(int64(x))
+	case uint32:
+		return otellog.Int64Value(int64(x))
+	case uint:
+		if uint64(x) > math.MaxInt64 {
+			return otellog.StringValue(fmt.Sprint(x))
+		}
+		return otellog.Int64Value(int64(x))
+	case uint64:
+		if x > math.MaxInt64 {
+			return otellog.StringValue(fmt.Sprint(x))
+		}
+		return otellog.Int64Value(int64(x))
+	case float32:
+		return otellog.Float64Value(float64(x))
+	case float64:
+		return otellog.Float64Value(x)
+	case []byte:
+		return otellog.BytesValue(x)
+	case map[string]any:
+		return otellog.MapValue(mapToOtelAttrs(x)...)
+	case []any:
+		vals := make([]otellog.Value, 0, len(x))
+		for _, e := range x {
+			vals = append(vals, anyToLogValue(e))
+		}
+		return otellog.SliceValue(vals...)
+	default:
+		return otellog.StringValue(fmt.Sprint(x))
+	}
+}
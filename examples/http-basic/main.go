@@ -19,6 +19,8 @@ func main() {
 		ServiceName:  "example-http-basic",
 		Environment:  "dev",
 		OtelEndpoint: "otel-collector:4317",
+
+		ExtractLegacyHeaders: true,
 	})
 	if err != nil {
 		log.Fatalf("eto init error: %v", err)
@@ -37,7 +39,7 @@ func main() {
 // otelMiddleware: ดึง trace จาก header + สร้าง server span + ใส่ trace_id คืนใน response
 func otelMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Extract trace จาก header (traceparent/baggage/x-trace-id ฯลฯ)
+		// Extract trace จาก header (traceparent/baggage, x-trace-id ถ้าเปิด ExtractLegacyHeaders)
 		ctx := eto.Propagate().FromHTTPRequest(r)
 
 		// Start server span
@@ -64,20 +66,14 @@ func otelMiddleware(next http.Handler) http.Handler {
 }
 
 func helloHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	start := time.Now()
-
-	_ = eto.Trace().
-		Name("http-basic.hello").
-		FromContext(ctx).
+	// span + log debug ตอนเริ่ม / จบ + counter + latency histogram ในการเรียกเดียว
+	_ = eto.Work(r.Context(), "http-basic.hello").
+		Attr("service", "example-http-basic").
+		Attr("route", "/hello").
+		Attr("method", r.Method).
+		Counter("http_requests_total").
+		Histogram("http_request_duration_ms").
 		Run(func(ctx context.Context) error {
-			// metrics: นับ request
-			eto.MetricCounter("http_requests_total").
-				Attr("service", "example-http-basic").
-				Attr("route", "/hello").
-				Attr("method", r.Method).
-				Add(ctx, 1)
-
 			eto.Log().
 				FromContext(ctx).
 				Info().
@@ -86,15 +82,6 @@ func helloHandler(w http.ResponseWriter, r *http.Request) {
 				Send()
 
 			fmt.Fprintln(w, "hello from http-basic example")
-
-			// metrics: latency
-			latencyMs := float64(time.Since(start).Milliseconds())
-			eto.MetricHistogram("http_request_duration_ms").
-				Attr("service", "example-http-basic").
-				Attr("route", "/hello").
-				Attr("method", r.Method).
-				Record(ctx, latencyMs)
-
 			return nil
 		})
 }
@@ -24,6 +24,8 @@ func main() {
 		Environment:   "dev",
 		OtelEndpoint:  "0.0.0.0:4317",
 		EnableMetrics: true,
+		// counter / histogram ได้ http.route จาก span ของ GinMiddleware เอง
+		MetricAttrsFromSpan: []string{"http.route"},
 	})
 	if err != nil {
 		log.Fatalf("eto init error: %v", err)
@@ -31,7 +33,11 @@ func main() {
 	defer shutdown(context.Background())
 
 	r := gin.Default()
-	r.Use(otelGinMiddleware())
+	r.Use(eto.GinMiddleware(
+		eto.WithRouteSLOs(map[string]time.Duration{
+			"/hello": 50 * time.Millisecond,
+		}),
+	))
 
 	r.GET("/hello", helloGin)
 
@@ -41,26 +47,6 @@ func main() {
 	}
 }
 
-func otelGinMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		ctx := eto.Propagate().FromHTTPRequest(c.Request)
-
-		ctx, end := tracer.Start(ctx, c.FullPath(),
-			"http.method", c.Request.Method,
-			"http.route", c.FullPath(),
-		)
-		defer end()
-
-		c.Request = c.Request.WithContext(ctx)
-
-		c.Next()
-
-		eto.Propagate().
-			FromContext(ctx).
-			ToHTTPResponse(c.Writer)
-	}
-}
-
 func helloGin(c *gin.Context) {
 	ctx := c.Request.Context()
 	start := time.Now()
@@ -69,7 +55,6 @@ func helloGin(c *gin.Context) {
 
 	metricer.Counter(ctx, "http_requests_total", 1,
 		"service", "example-http-gin",
-		"route", "/hello",
 		"method", c.Request.Method,
 	)
 
@@ -82,9 +67,9 @@ func helloGin(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"message": "hello from http-gin example"})
 
 			latencyMs := float64(time.Since(start).Milliseconds())
-			metricer.Histogram(ctx, "http_request_duration_ms", latencyMs,
+			// ctx ของ request (span ของ middleware) ไม่ใช่ span gin.hello ที่ไม่มี http.route
+			metricer.Histogram(c.Request.Context(), "http_request_duration_ms", latencyMs,
 				"service", "example-http-gin",
-				"route", "/hello",
 				"method", c.Request.Method,
 			)
 			return nil
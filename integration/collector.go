@@ -0,0 +1,176 @@
+// Package integration รัน otel-collector จริงใน docker (testcontainers) ที่เขียน OTLP payload ลงไฟล์
+// แล้วอ่านกลับมาให้ test ตรวจได้ ใช้เป็น template สำหรับทดสอบ pipeline ของ service ตัวเองได้
+//
+// แยกเป็น module ของตัวเองเพื่อไม่ให้ testcontainers ติดไปกับ dependency ของ otelgo
+// รันด้วย: cd integration && go test -tags integration ./...
+package integration
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// CollectorImage image ที่ใช้ (ต้องเป็น contrib เพราะใช้ file exporter)
+const CollectorImage = "otel/opentelemetry-collector-contrib:0.136.0"
+
+// Collector คือ otel-collector ที่รันอยู่ใน container
+type Collector struct {
+	container testcontainers.Container
+	Endpoint  string // host:port ของ OTLP gRPC ใช้เป็น eto.Config.OtelEndpoint ได้เลย
+}
+
+// StartCollector เปิด collector ด้วย collector-config.yaml ใน package นี้ แล้วรอจน OTLP port พร้อม
+func StartCollector(ctx context.Context) (*Collector, error) {
+	_, file, _, _ := runtime.Caller(0)
+	config := filepath.Join(filepath.Dir(file), "collector-config.yaml")
+
+	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        CollectorImage,
+			ExposedPorts: []string{"4317/tcp"},
+			Cmd:          []string{"--config=/etc/otelcol/config.yaml"},
+			Files: []testcontainers.ContainerFile{{
+				HostFilePath:      config,
+				ContainerFilePath: "/etc/otelcol/config.yaml",
+				FileMode:          0o644,
+			}},
+			WaitingFor: wait.ForListeningPort("4317/tcp").WithStartupTimeout(time.Minute),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("integration: start collector: %w", err)
+	}
+
+	endpoint, err := c.PortEndpoint(ctx, "4317/tcp", "")
+	if err != nil {
+		_ = c.Terminate(ctx)
+		return nil, fmt.Errorf("integration: collector endpoint: %w", err)
+	}
+
+	return &Collector{container: c, Endpoint: endpoint}, nil
+}
+
+// Terminate ปิด container
+func (c *Collector) Terminate(ctx context.Context) error {
+	return c.container.Terminate(ctx)
+}
+
+// Traces อ่าน span ทั้งหมดที่ collector export ลงไฟล์แล้ว
+func (c *Collector) Traces(ctx context.Context) ([]Span, error) {
+	var out []Span
+	err := c.readLines(ctx, "/tmp/otel/traces.json", func(line []byte) error {
+		var req tracesPayload
+		if err := json.Unmarshal(line, &req); err != nil {
+			return err
+		}
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					s.Resource = rs.Resource.Attributes
+					out = append(out, s)
+				}
+			}
+		}
+		return nil
+	})
+	return out, err
+}
+
+// MetricNames อ่านชื่อ metric ทั้งหมดที่ collector export ลงไฟล์แล้ว
+func (c *Collector) MetricNames(ctx context.Context) (map[string]bool, error) {
+	out := map[string]bool{}
+	err := c.readLines(ctx, "/tmp/otel/metrics.json", func(line []byte) error {
+		var req metricsPayload
+		if err := json.Unmarshal(line, &req); err != nil {
+			return err
+		}
+		for _, rm := range req.ResourceMetrics {
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					out[m.Name] = true
+				}
+			}
+		}
+		return nil
+	})
+	return out, err
+}
+
+// Logs อ่าน log record ทั้งหมดที่ collector export ลงไฟล์แล้ว
+func (c *Collector) Logs(ctx context.Context) ([]LogRecord, error) {
+	var out []LogRecord
+	err := c.readLines(ctx, "/tmp/otel/logs.json", func(line []byte) error {
+		var req logsPayload
+		if err := json.Unmarshal(line, &req); err != nil {
+			return err
+		}
+		for _, rl := range req.ResourceLogs {
+			for _, sl := range rl.ScopeLogs {
+				out = append(out, sl.LogRecords...)
+			}
+		}
+		return nil
+	})
+	return out, err
+}
+
+// WaitFor เรียก check ซ้ำจนคืน true หรือหมดเวลา (file exporter flush แบบ async)
+func WaitFor(ctx context.Context, timeout time.Duration, check func(ctx context.Context) (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		ok, err := check(ctx)
+		if ok {
+			return nil
+		}
+		lastErr = err
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("integration: timed out: %w", lastErr)
+			}
+			return fmt.Errorf("integration: timed out")
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func (c *Collector) readLines(ctx context.Context, path string, fn func(line []byte) error) error {
+	rc, err := c.container.CopyFileFromContainer(ctx, path)
+	if err != nil {
+		// ยังไม่มีไฟล์ = collector ยังไม่ได้ export อะไร
+		return fmt.Errorf("integration: read %s: %w", path, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return fmt.Errorf("integration: decode %s: %w", path, err)
+		}
+	}
+	return sc.Err()
+}
@@ -0,0 +1,85 @@
+package integration
+
+// โครงสร้าง OTLP/JSON แบบย่อ (เฉพาะ field ที่ test ใช้ตรวจ) ตามที่ file exporter เขียนออกมา
+
+// KeyValue attribute ของ OTLP
+type KeyValue struct {
+	Key   string   `json:"key"`
+	Value AnyValue `json:"value"`
+}
+
+// AnyValue ค่าของ attribute (OTLP/JSON เขียน int64 เป็น string)
+type AnyValue struct {
+	StringValue string   `json:"stringValue,omitempty"`
+	IntValue    string   `json:"intValue,omitempty"`
+	BoolValue   bool     `json:"boolValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// Attrs ช่วยหา attribute ตาม key
+type Attrs []KeyValue
+
+// Get คืนค่า string ของ key (int จะได้เป็น string ตาม OTLP/JSON)
+func (a Attrs) Get(key string) (string, bool) {
+	for _, kv := range a {
+		if kv.Key != key {
+			continue
+		}
+		if kv.Value.StringValue != "" {
+			return kv.Value.StringValue, true
+		}
+		return kv.Value.IntValue, true
+	}
+	return "", false
+}
+
+type resource struct {
+	Attributes Attrs `json:"attributes"`
+}
+
+// Span ที่ collector export
+type Span struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Attributes   Attrs  `json:"attributes"`
+	Resource     Attrs  `json:"-"`
+}
+
+type tracesPayload struct {
+	ResourceSpans []struct {
+		Resource   resource `json:"resource"`
+		ScopeSpans []struct {
+			Spans []Span `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+type metricsPayload struct {
+	ResourceMetrics []struct {
+		ScopeMetrics []struct {
+			Metrics []struct {
+				Name string `json:"name"`
+			} `json:"metrics"`
+		} `json:"scopeMetrics"`
+	} `json:"resourceMetrics"`
+}
+
+// LogRecord ที่ collector export
+type LogRecord struct {
+	SeverityText string   `json:"severityText"`
+	Body         AnyValue `json:"body"`
+	TraceID      string   `json:"traceId"`
+	SpanID       string   `json:"spanId"`
+	Attributes   Attrs    `json:"attributes"`
+}
+
+type logsPayload struct {
+	ResourceLogs []struct {
+		ScopeLogs []struct {
+			LogRecords []LogRecord `json:"logRecords"`
+		} `json:"scopeLogs"`
+	} `json:"resourceLogs"`
+}
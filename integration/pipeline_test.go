@@ -0,0 +1,107 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Maximumsoft-Co-LTD/otelgo/eto"
+	"github.com/gin-gonic/gin"
+)
+
+// TestPipeline: Init → GinMiddleware + metric + log → export ผ่าน collector จริง → ตรวจ payload ที่ออกมา
+func TestPipeline(t *testing.T) {
+	ctx := context.Background()
+
+	col, err := StartCollector(ctx)
+	if err != nil {
+		t.Fatalf("start collector: %v", err)
+	}
+	t.Cleanup(func() { _ = col.Terminate(context.Background()) })
+
+	initCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	shutdown, err := eto.Init(initCtx, eto.Config{
+		ServiceName:   "integration",
+		Environment:   "test",
+		OtelEndpoint:  col.Endpoint,
+		EnableMetrics: true,
+	})
+	if err != nil {
+		t.Fatalf("eto.Init: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(eto.GinMiddleware())
+	r.GET("/hello/:name", func(c *gin.Context) {
+		eto.Log().FromContext(c.Request.Context()).Info().Msg("hello handler").Field("name", c.Param("name")).Send()
+		eto.MetricCounter("integration_hello_total").Add(c.Request.Context(), 1)
+		c.Status(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello/world", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	if rec.Header().Get("x-trace-id") == "" {
+		t.Errorf("response is missing x-trace-id header")
+	}
+
+	if err := eto.Flush(ctx); err != nil {
+		t.Fatalf("eto.Flush: %v", err)
+	}
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	var server Span
+	err = WaitFor(ctx, 30*time.Second, func(ctx context.Context) (bool, error) {
+		spans, err := col.Traces(ctx)
+		for _, s := range spans {
+			if s.Name == "/hello/:name" {
+				server = s
+				return true, nil
+			}
+		}
+		return false, err
+	})
+	if err != nil {
+		t.Fatalf("server span not exported: %v", err)
+	}
+	if got, _ := server.Attributes.Get("http.route"); got != "/hello/:name" {
+		t.Errorf("http.route = %q", got)
+	}
+	if got, _ := server.Resource.Get("service.name"); got != "integration" {
+		t.Errorf("service.name = %q", got)
+	}
+
+	err = WaitFor(ctx, 30*time.Second, func(ctx context.Context) (bool, error) {
+		names, err := col.MetricNames(ctx)
+		return names["http_requests_total"] && names["integration_hello_total"], err
+	})
+	if err != nil {
+		t.Fatalf("metrics not exported: %v", err)
+	}
+
+	err = WaitFor(ctx, 30*time.Second, func(ctx context.Context) (bool, error) {
+		logs, err := col.Logs(ctx)
+		for _, l := range logs {
+			if l.Body.StringValue == "hello handler" {
+				if got, _ := l.Attributes.Get("trace_id"); got != server.TraceID {
+					t.Errorf("log trace_id = %q, want %q", got, server.TraceID)
+				}
+				return true, nil
+			}
+		}
+		return false, err
+	})
+	if err != nil {
+		t.Fatalf("log not exported: %v", err)
+	}
+}
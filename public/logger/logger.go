@@ -2,8 +2,11 @@ package logger
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/Maximumsoft-Co-LTD/otelgo/eto"
+	"go.uber.org/zap"
 )
 
 // Info logs an info-level message with optional fields.
@@ -31,25 +34,121 @@ func Warn(ctx context.Context, msg string, fields ...any) {
 }
 
 // Error logs an error-level message with optional fields.
-// Usage: logger.Error(ctx, "message", "key1", value1, "key2", value2)
+// Usage: logger.Error(ctx, "message", err, "key1", value1, "key2", value2)
 func Error(ctx context.Context, msg string, fields ...any) {
 	builder := eto.Log().FromContext(ctx).Error().Msg(msg)
 	addFields(builder, fields...)
 	builder.Send()
 }
 
+// Infof logs an info-level message formatted with fmt.Sprintf.
+// Usage: logger.Infof(ctx, "processed %d items in %s", n, d)
+func Infof(ctx context.Context, format string, args ...any) {
+	eto.Log().FromContext(ctx).Info().Msgf(format, args...).Send()
+}
+
+// Debugf logs a debug-level message formatted with fmt.Sprintf.
+func Debugf(ctx context.Context, format string, args ...any) {
+	eto.Log().FromContext(ctx).Debug().Msgf(format, args...).Send()
+}
+
+// Warnf logs a warning-level message formatted with fmt.Sprintf.
+func Warnf(ctx context.Context, format string, args ...any) {
+	eto.Log().FromContext(ctx).Warn().Msgf(format, args...).Send()
+}
+
+// Errorf logs an error-level message formatted with fmt.Sprintf.
+// A %w verb works as in fmt.Errorf: the wrapped error is attached with LogBuilder.Err.
+func Errorf(ctx context.Context, format string, args ...any) {
+	err := fmt.Errorf(format, args...)
+	eto.Log().FromContext(ctx).Error().Msg(err.Error()).Err(errors.Unwrap(err)).Send()
+}
+
 // addFields adds key-value pairs to the log builder.
 // Fields should be provided as alternating key-value pairs: "key1", value1, "key2", value2, ...
+// An error may be passed in place of a key and is attached with LogBuilder.Err:
+//
+//	logger.Error(ctx, "save user failed", err, "user_id", id)
 func addFields(builder *eto.LogBuilder, fields ...any) {
-	if len(fields)%2 != 0 {
-		// If odd number of fields, ignore the last one
-		fields = fields[:len(fields)-1]
+	for i := 0; i < len(fields); i++ {
+		if err, ok := fields[i].(error); ok {
+			builder.Err(err)
+			continue
+		}
+		if i+1 >= len(fields) {
+			// key without a value: ignore it
+			break
+		}
+		if key, ok := fields[i].(string); ok {
+			builder.Field(key, fields[i+1])
+		}
+		i++
 	}
-	for i := 0; i < len(fields); i += 2 {
-		key, ok := fields[i].(string)
-		if !ok {
+}
+
+// Logger is a scoped logger whose preset context and fields are included in every record.
+// It is immutable and safe for concurrent use.
+type Logger struct {
+	l *eto.Logger
+}
+
+// With returns a Logger bound to ctx with the given key-value pairs preset.
+// Usage:
+//
+//	log := logger.With(ctx, "component", "billing", "order_id", id)
+//	log.Info("charged", "amount", 100)
+func With(ctx context.Context, fields ...any) *Logger {
+	return &Logger{l: withPairs(eto.Log().FromContext(ctx).WithFields(), fields...)}
+}
+
+// With returns a child Logger with additional preset key-value pairs.
+func (l *Logger) With(fields ...any) *Logger {
+	return &Logger{l: withPairs(l.l, fields...)}
+}
+
+// Info logs an info-level message with the preset fields plus optional fields.
+func (l *Logger) Info(msg string, fields ...any) {
+	builder := l.l.Log().Info().Msg(msg)
+	addFields(builder, fields...)
+	builder.Send()
+}
+
+// Debug logs a debug-level message with the preset fields plus optional fields.
+func (l *Logger) Debug(msg string, fields ...any) {
+	builder := l.l.Log().Debug().Msg(msg)
+	addFields(builder, fields...)
+	builder.Send()
+}
+
+// Warn logs a warning-level message with the preset fields plus optional fields.
+func (l *Logger) Warn(msg string, fields ...any) {
+	builder := l.l.Log().Warn().Msg(msg)
+	addFields(builder, fields...)
+	builder.Send()
+}
+
+// Error logs an error-level message with the preset fields plus optional fields.
+func (l *Logger) Error(msg string, fields ...any) {
+	builder := l.l.Log().Error().Msg(msg)
+	addFields(builder, fields...)
+	builder.Send()
+}
+
+// withPairs presets alternating key-value pairs, following the same rules as addFields.
+// A preset error is stored as an "error" field.
+func withPairs(l *eto.Logger, fields ...any) *eto.Logger {
+	for i := 0; i < len(fields); i++ {
+		if err, ok := fields[i].(error); ok {
+			l = l.WithFields(zap.Error(err))
 			continue
 		}
-		builder.Field(key, fields[i+1])
+		if i+1 >= len(fields) {
+			break
+		}
+		if key, ok := fields[i].(string); ok {
+			l = l.With(key, fields[i+1])
+		}
+		i++
 	}
+	return l
 }
@@ -2,6 +2,7 @@ package metricer
 
 import (
 	"context"
+	"time"
 
 	"github.com/Maximumsoft-Co-LTD/otelgo/eto"
 )
@@ -48,3 +49,29 @@ func Histogram(ctx context.Context, name string, value float64, attrs ...any) {
 
 	builder.Record(ctx, value)
 }
+
+// Timer starts measuring and returns a stop function that records the elapsed
+// time in milliseconds into the histogram with the given name.
+// Usage:
+//
+//	stop := metricer.Timer(ctx, "operation_duration_ms", "operation", "sync-users")
+//	defer stop()
+func Timer(ctx context.Context, name string, attrs ...any) func() {
+	start := time.Now()
+	return func() {
+		Histogram(ctx, name, float64(time.Since(start))/float64(time.Millisecond), attrs...)
+	}
+}
+
+// Time runs fn and records its elapsed time in milliseconds into the histogram
+// with the given name. The error from fn is returned as is.
+// Usage:
+//
+//	err := metricer.Time(ctx, "operation_duration_ms", func(ctx context.Context) error {
+//	    return syncUsers(ctx)
+//	}, "operation", "sync-users")
+func Time(ctx context.Context, name string, fn func(ctx context.Context) error, attrs ...any) error {
+	stop := Timer(ctx, name, attrs...)
+	defer stop()
+	return fn(ctx)
+}
@@ -3,10 +3,13 @@ package tracer
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/Maximumsoft-Co-LTD/otelgo/eto"
+	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 )
 
 // Start starts a new span with the given name and context.
@@ -32,7 +35,7 @@ func Start(ctx context.Context, name string, attrs ...any) (context.Context, fun
 	}
 
 	ctx, span := builder.Start()
-	return ctx, func() { span.End() }
+	return ctx, func() { eto.EndSpan(span) }
 }
 
 // Run executes a function within a span, automatically handling errors.
@@ -74,7 +77,7 @@ func StartServer(ctx context.Context, name string, attrs ...any) (context.Contex
 	}
 
 	ctx, span := builder.Start()
-	return ctx, func() { span.End() }
+	return ctx, func() { eto.EndSpan(span) }
 }
 
 // StartClient starts a client span (for HTTP clients, gRPC clients, etc.).
@@ -100,7 +103,7 @@ func StartClient(ctx context.Context, name string, attrs ...any) (context.Contex
 	}
 
 	ctx, span := builder.Start()
-	return ctx, func() { span.End() }
+	return ctx, func() { eto.EndSpan(span) }
 }
 
 // StartConsumer starts a consumer span (for message queue consumers).
@@ -126,7 +129,7 @@ func StartConsumer(ctx context.Context, name string, attrs ...any) (context.Cont
 	}
 
 	ctx, span := builder.Start()
-	return ctx, func() { span.End() }
+	return ctx, func() { eto.EndSpan(span) }
 }
 
 // StartProducer starts a producer span (for message queue producers).
@@ -152,7 +155,43 @@ func StartProducer(ctx context.Context, name string, attrs ...any) (context.Cont
 	}
 
 	ctx, span := builder.Start()
-	return ctx, func() { span.End() }
+	return ctx, func() { eto.EndSpan(span) }
+}
+
+// AsyncFromGin starts a span for work that outlives the request, e.g. a goroutine
+// spawned with c.Copy(). The new span is a root span linked to the request span,
+// and the returned context keeps request values (baggage, tenant, ...) but is not
+// canceled when the request finishes.
+// Usage:
+//
+//	ctx, end := tracer.AsyncFromGin(c, "send-email")
+//	go func() {
+//	    defer end()
+//	    sendEmail(ctx)
+//	}()
+func AsyncFromGin(c *gin.Context, name string, attrs ...any) (context.Context, func()) {
+	parent := context.Background()
+	if c != nil && c.Request != nil {
+		parent = c.Request.Context()
+	}
+	link := trace.SpanContextFromContext(parent)
+
+	// Detach from request cancellation; an empty SpanContext makes the new span a root.
+	ctx := trace.ContextWithSpanContext(context.WithoutCancel(parent), trace.SpanContext{})
+
+	builder := eto.Trace().
+		Name(name).
+		FromContext(ctx).
+		Link(link, attribute.String("link.type", "gin.async"))
+
+	for i := 0; i < len(attrs)-1; i += 2 {
+		if key, ok := attrs[i].(string); ok {
+			builder = builder.Attr(key, attrs[i+1])
+		}
+	}
+
+	ctx, span := builder.Start()
+	return ctx, func() { eto.EndSpan(span) }
 }
 
 // Builder returns the underlying eto.Trace() builder for advanced usage.
@@ -188,3 +227,43 @@ func Attr(key string, val any) attribute.KeyValue {
 		return attribute.String(key, fmt.Sprintf("%v", v))
 	}
 }
+
+// GRPCUnaryServerInterceptor returns a unary server interceptor that extracts the trace
+// context from incoming metadata, starts a server span with rpc.* attributes, records the
+// gRPC status code and emits grpc_server_requests_total / grpc_server_duration_ms.
+// Usage:
+//
+//	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(tracer.GRPCUnaryServerInterceptor()))
+func GRPCUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return eto.GRPCUnaryServerInterceptor()
+}
+
+// GRPCStreamServerInterceptor is the streaming counterpart of GRPCUnaryServerInterceptor.
+// The span also records how many messages were sent and received.
+// Usage:
+//
+//	srv := grpc.NewServer(grpc.ChainStreamInterceptor(tracer.GRPCStreamServerInterceptor()))
+func GRPCStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return eto.GRPCStreamServerInterceptor()
+}
+
+// TransportOption configures NewTransport.
+type TransportOption = eto.HTTPTransportOption
+
+// WithLegacyHeaders makes NewTransport send x-trace-id / x-span-id in addition to
+// traceparent, for downstream services that only read the legacy headers.
+func WithLegacyHeaders() TransportOption {
+	return eto.WithRequestHeaders(eto.HeaderFormatBoth)
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil) so every outbound request
+// gets a client span, carries the trace context and is counted in
+// http_client_requests_total / http_client_request_duration_ms.
+// Usage:
+//
+//	client := &http.Client{Transport: tracer.NewTransport(nil, tracer.WithLegacyHeaders())}
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//	resp, err := client.Do(req)
+func NewTransport(base http.RoundTripper, opts ...TransportOption) http.RoundTripper {
+	return eto.NewHTTPTransport(base, opts...)
+}